@@ -56,4 +56,15 @@ lotus-seed aggregate-manifests "${manifest_files[@]}" > ${SHARED_CONFIGS}/manife
 # is this step flaky/nondeterministic? it was in the Dockerfile. Do we need retries here?
 lotus-seed genesis add-miner ${SHARED_CONFIGS}/localnet.json ${SHARED_CONFIGS}/manifest.json
 
+# append sectorless miners generated by genesis-prep --miners after the
+# pre-sealed ones; genesis assigns miner IDs by position (t01000 + index)
+if [ -s "${SHARED_CONFIGS}/miners.json" ]; then
+  echo "Adding generated genesis miners..."
+  jq --slurpfile miners ${SHARED_CONFIGS}/miners.json \
+     '.Miners += [$miners[0][] | {Owner, Worker, PeerId, SectorSize, MarketBalance: "0", PowerBalance: "0", Sectors: []}]
+      | .Miners |= [to_entries[] | .value.ID = "t0\(1000 + .key)" | .value]' \
+     ${SHARED_CONFIGS}/localnet.json > ${SHARED_CONFIGS}/localnet.tmp \
+     && mv ${SHARED_CONFIGS}/localnet.tmp ${SHARED_CONFIGS}/localnet.json
+fi
+
 echo "Genesis setup complete for $NUM_LOTUS_CLIENTS miner(s)."
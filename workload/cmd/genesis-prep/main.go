@@ -2,19 +2,30 @@ package main
 
 import (
 	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/csv"
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"io"
 	"log"
+	"math"
+	"math/big"
 	"os"
+	"strings"
 
 	"golang.org/x/crypto/hkdf"
 
 	"github.com/filecoin-project/lotus/chain/types"
 	"github.com/filecoin-project/lotus/chain/wallet/key"
 	_ "github.com/filecoin-project/lotus/lib/sigs/secp"
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/urfave/cli/v2"
+
+	"workload/internal/bls"
+	"workload/internal/genesis"
 )
 
 type GenesisAccount struct {
@@ -25,9 +36,51 @@ type GenesisAccount struct {
 	} `json:"Meta"`
 }
 
+// KeystoreEntry is one wallet in the lotus-format stress_keystore.json, the
+// format the stress engine's loadKeystore reads: a JSON array of
+//
+//	{"Address": "f1...", "PrivateKey": "<hex>", "Type": "secp256k1"|"bls", "Balance": "<attoFIL>"}
+//
+// Type and Balance may be absent.
 type KeystoreEntry struct {
 	Address    string `json:"Address"`
-	PrivateKey string `json:"PrivateKey"` // Hex encoded
+	PrivateKey string `json:"PrivateKey"`        // Hex encoded
+	Type       string `json:"Type,omitempty"`    // types.KeyType; empty means secp256k1
+	Balance    string `json:"Balance,omitempty"` // genesis attoFIL; only with --keystore-balances
+}
+
+// ForestKeyInfo is one wallet in the forest-format forest_keystore.json,
+// laid out like Forest's unencrypted keystore.json (encrypt_keystore =
+// false): a JSON object keyed by "wallet-<address>" whose values are
+//
+//	{"key_type": 1|2, "private_key": "<base64>"}
+//
+// key_type is Forest's numeric SignatureType (1 = secp256k1, 2 = bls).
+type ForestKeyInfo struct {
+	KeyType    int    `json:"key_type"`
+	PrivateKey string `json:"private_key"` // Base64 encoded
+}
+
+// forestSignatureType maps a lotus key type to Forest's SignatureType.
+func forestSignatureType(kt types.KeyType) int {
+	if kt == types.KTBLS {
+		return 2
+	}
+	return 1
+}
+
+// MinerEntry describes one generated genesis miner. Owner, Worker, PeerId and
+// SectorSize use the field names of the Lotus genesis template's Miners
+// entries; the keys let downstream tooling run the miner and sign as its
+// worker.
+type MinerEntry struct {
+	Owner      string `json:"Owner"`
+	OwnerKey   string `json:"OwnerKey"` // Hex encoded secp256k1
+	Worker     string `json:"Worker"`
+	WorkerKey  string `json:"WorkerKey"` // Hex encoded BLS
+	PeerId     string `json:"PeerId"`
+	PeerKey    string `json:"PeerKey"` // Hex encoded libp2p private key
+	SectorSize uint64 `json:"SectorSize"`
 }
 
 func main() {
@@ -57,9 +110,70 @@ func main() {
 				Value: "antithesis-stress-genesis-v1",
 				Usage: "Master seed for deterministic key derivation",
 			},
+			&cli.StringFlag{
+				Name:  "key-type",
+				Value: "secp256k1",
+				Usage: "Wallet key type: secp256k1, bls, or mixed (alternating)",
+			},
+			&cli.StringFlag{
+				Name:  "balance-distribution",
+				Value: "uniform",
+				Usage: "Per-wallet balances: uniform (every wallet gets --balance), powerlaw (whales and dust capped at --balance), or explicit:<csv> (one attoFIL balance per line, in wallet order)",
+			},
+			&cli.BoolFlag{
+				Name:  "keystore-balances",
+				Usage: "Also record each wallet's genesis balance in stress_keystore.json",
+			},
+			&cli.StringFlag{
+				Name:  "format",
+				Value: "lotus",
+				Usage: "Keystore format: lotus (stress_keystore.json, read by the stress engine), forest (forest_keystore.json), or both",
+			},
+			&cli.IntFlag{
+				Name:  "miners",
+				Value: 0,
+				Usage: "Number of genesis miners to generate owner/worker keys for (written to miners.json)",
+			},
+			&cli.Uint64Flag{
+				Name:  "miner-sector-size",
+				Value: 2048,
+				Usage: "Sector size in bytes of the generated miners",
+			},
 		},
 		Action: func(c *cli.Context) error {
-			return generate(c.Int("count"), c.String("out"), c.String("balance"), c.String("seed"))
+			keyType := c.String("key-type")
+			switch keyType {
+			case "secp256k1", "bls", "mixed":
+			default:
+				return fmt.Errorf("unknown --key-type %q (want secp256k1, bls or mixed)", keyType)
+			}
+			format := c.String("format")
+			switch format {
+			case "lotus", "forest", "both":
+			default:
+				return fmt.Errorf("unknown --format %q (want lotus, forest or both)", format)
+			}
+			balances, err := walletBalances(c.String("balance-distribution"), c.String("balance"), c.String("seed"), c.Int("count"))
+			if err != nil {
+				return err
+			}
+			// miners.json is written first because genesis setup starts as
+			// soon as genesis_allocs.json exists.
+			var minerAccs []GenesisAccount
+			if n := c.Int("miners"); n > 0 {
+				if minerAccs, err = generateMiners(n, c.String("out"), c.String("balance"), c.String("seed"), c.Uint64("miner-sector-size")); err != nil {
+					return err
+				}
+			}
+			manifest := genesis.Manifest{
+				Seed:                c.String("seed"),
+				Count:               c.Int("count"),
+				Balance:             c.String("balance"),
+				BalanceDistribution: c.String("balance-distribution"),
+				KeyType:             keyType,
+				Miners:              c.Int("miners"),
+			}
+			return generate(c.Int("count"), c.String("out"), balances, c.String("seed"), keyType, format, c.Bool("keystore-balances"), minerAccs, manifest)
 		},
 	}
 
@@ -68,9 +182,9 @@ func main() {
 	}
 }
 
-// derivePrivKey derives a secp256k1 private key deterministically from a master
-// seed and wallet index using HKDF-SHA256. The same seed+index always produces
-// the same 32-byte key, so wallets are stable across container restarts.
+// derivePrivKey derives 32 bytes of private key material deterministically from
+// a master seed and wallet index using HKDF-SHA256. The same seed+index always
+// produces the same key, so wallets are stable across container restarts.
 func derivePrivKey(masterSeed string, index int) ([]byte, error) {
 	info := fmt.Sprintf("stress-wallet-%d", index)
 	r := hkdf.New(sha256.New, []byte(masterSeed), nil, []byte(info))
@@ -81,46 +195,258 @@ func derivePrivKey(masterSeed string, index int) ([]byte, error) {
 	return pk, nil
 }
 
-func generate(count int, outDir string, balance string, seed string) error {
-	log.Printf("Generating %d wallets (deterministic, seed=%q)...", count, seed)
+// walletKeyType returns the key type of wallet i under the --key-type mode.
+// Mixed alternates secp256k1 (even) and BLS (odd).
+func walletKeyType(mode string, i int) types.KeyType {
+	if mode == "bls" || (mode == "mixed" && i%2 == 1) {
+		return types.KTBLS
+	}
+	return types.KTSecp256k1
+}
+
+// powerlawAlpha and powerlawRange shape the powerlaw distribution: balances
+// follow a Pareto tail with exponent alpha between --balance/range and
+// --balance. With alpha 0.5 about 1% of wallets hold the full --balance and
+// the median wallet holds 4x the floor, which still covers gas.
+const (
+	powerlawAlpha = 0.5
+	powerlawRange = 10_000
+)
+
+// walletBalances returns the genesis balance in attoFIL of each of the count
+// wallets under the --balance-distribution mode.
+func walletBalances(mode, balance, seed string, count int) ([]string, error) {
+	ceiling, ok := new(big.Int).SetString(balance, 10)
+	if !ok || ceiling.Sign() <= 0 {
+		return nil, fmt.Errorf("invalid --balance %q", balance)
+	}
+
+	balances := make([]string, count)
+	switch {
+	case mode == "uniform":
+		for i := range balances {
+			balances[i] = ceiling.String()
+		}
+	case mode == "powerlaw":
+		floor := new(big.Float).SetInt(new(big.Int).Div(ceiling, big.NewInt(powerlawRange)))
+		for i := range balances {
+			u, err := deriveUnit(seed, i)
+			if err != nil {
+				return nil, fmt.Errorf("failed to derive balance %d: %w", i, err)
+			}
+			mult := math.Min(math.Pow(u, -1/powerlawAlpha), powerlawRange)
+			bal, _ := new(big.Float).Mul(floor, big.NewFloat(mult)).Int(nil)
+			balances[i] = bal.String()
+		}
+	case strings.HasPrefix(mode, "explicit:"):
+		rows, err := readBalanceCSV(strings.TrimPrefix(mode, "explicit:"))
+		if err != nil {
+			return nil, err
+		}
+		if len(rows) < count {
+			return nil, fmt.Errorf("balance csv has %d rows, need %d", len(rows), count)
+		}
+		copy(balances, rows)
+	default:
+		return nil, fmt.Errorf("unknown --balance-distribution %q (want uniform, powerlaw or explicit:<csv>)", mode)
+	}
+	return balances, nil
+}
+
+// deriveUnit derives a uniform value in (0, 1] for wallet index from the
+// HKDF stream, so powerlaw balances are reproducible for a given seed.
+func deriveUnit(masterSeed string, index int) (float64, error) {
+	info := fmt.Sprintf("stress-balance-%d", index)
+	r := hkdf.New(sha256.New, []byte(masterSeed), nil, []byte(info))
+	var buf [8]byte
+	if _, err := io.ReadFull(r, buf[:]); err != nil {
+		return 0, fmt.Errorf("hkdf read failed: %w", err)
+	}
+	return float64(binary.BigEndian.Uint64(buf[:])>>11+1) / (1 << 53), nil
+}
+
+// readBalanceCSV reads one attoFIL balance per row from the first column of
+// path. Lines starting with # are ignored.
+func readBalanceCSV(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("open balance csv: %w", err)
+	}
+	defer f.Close()
+
+	r := csv.NewReader(f)
+	r.Comment = '#'
+	r.FieldsPerRecord = -1
+	records, err := r.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("parse balance csv %s: %w", path, err)
+	}
+	balances := make([]string, 0, len(records))
+	for n, rec := range records {
+		v := strings.TrimSpace(rec[0])
+		bal, ok := new(big.Int).SetString(v, 10)
+		if !ok || bal.Sign() < 0 {
+			return nil, fmt.Errorf("balance csv %s row %d: invalid balance %q", path, n+1, v)
+		}
+		balances = append(balances, bal.String())
+	}
+	return balances, nil
+}
+
+func generate(count int, outDir string, balances []string, seed string, keyType string, format string, keystoreBalances bool, minerAccs []GenesisAccount, manifest genesis.Manifest) error {
+	log.Printf("Generating %d wallets (deterministic, seed=%q, key-type=%s)...", count, seed, keyType)
 
 	var genesisAccs []GenesisAccount
 	var keystore []KeystoreEntry
+	var addresses []string
+	forestKeystore := make(map[string]ForestKeyInfo, count)
 
 	for i := 0; i < count; i++ {
 		pk, err := derivePrivKey(seed, i)
 		if err != nil {
 			return fmt.Errorf("failed to derive key %d: %w", i, err)
 		}
-		k, err := key.NewKey(types.KeyInfo{Type: types.KTSecp256k1, PrivateKey: pk})
+		kt := walletKeyType(keyType, i)
+		if kt == types.KTBLS {
+			// BLS keys must be reduced below the group order
+			if pk, err = bls.PrivateKeyFromSeed(pk); err != nil {
+				return fmt.Errorf("failed to derive bls key %d: %w", i, err)
+			}
+		}
+		k, err := key.NewKey(types.KeyInfo{Type: kt, PrivateKey: pk})
 		if err != nil {
 			return fmt.Errorf("failed to build key %d: %w", i, err)
 		}
 		genesisAccs = append(genesisAccs, GenesisAccount{
 			Type:    "account",
-			Balance: balance,
+			Balance: balances[i],
 			Meta: struct {
 				Owner string `json:"Owner"`
 			}{Owner: k.Address.String()},
 		})
 
-		keystore = append(keystore, KeystoreEntry{
+		entry := KeystoreEntry{
 			Address:    k.Address.String(),
 			PrivateKey: hex.EncodeToString(k.KeyInfo.PrivateKey),
-		})
+			Type:       string(kt),
+		}
+		if keystoreBalances {
+			entry.Balance = balances[i]
+		}
+		keystore = append(keystore, entry)
+		addresses = append(addresses, entry.Address)
+		forestKeystore["wallet-"+k.Address.String()] = ForestKeyInfo{
+			KeyType:    forestSignatureType(kt),
+			PrivateKey: base64.StdEncoding.EncodeToString(k.KeyInfo.PrivateKey),
+		}
 	}
 
-	if err := writeJson(fmt.Sprintf("%s/genesis_allocs.json", outDir), genesisAccs); err != nil {
+	// The manifest lets the stress engine check it loaded this wallet set.
+	manifest.AddressesSHA256 = genesis.AddressChecksum(addresses)
+	if err := writeJson(fmt.Sprintf("%s/%s", outDir, genesis.ManifestFile), manifest); err != nil {
 		return err
 	}
-	if err := writeJson(fmt.Sprintf("%s/stress_keystore.json", outDir), keystore); err != nil {
+
+	genesisAccs = append(genesisAccs, minerAccs...)
+	if err := writeJson(fmt.Sprintf("%s/genesis_allocs.json", outDir), genesisAccs); err != nil {
 		return err
 	}
+	if format != "forest" {
+		if err := writeJson(fmt.Sprintf("%s/stress_keystore.json", outDir), keystore); err != nil {
+			return err
+		}
+	}
+	if format != "lotus" {
+		if err := writeJson(fmt.Sprintf("%s/forest_keystore.json", outDir), forestKeystore); err != nil {
+			return err
+		}
+	}
 
 	log.Printf("Success! Wrote keys to %s", outDir)
 	return nil
 }
 
+// deriveMinerKeys derives a miner's owner (secp256k1) and worker (BLS) keys
+// and its libp2p identity from one HKDF stream under the label
+// "stress-miner-<index>", disjoint from the wallet labels.
+func deriveMinerKeys(masterSeed string, index int) (owner, worker *key.Key, peerKey crypto.PrivKey, err error) {
+	info := fmt.Sprintf("stress-miner-%d", index)
+	r := hkdf.New(sha256.New, []byte(masterSeed), nil, []byte(info))
+
+	ownerPK := make([]byte, 32)
+	workerSeed := make([]byte, 32)
+	if _, err := io.ReadFull(r, ownerPK); err != nil {
+		return nil, nil, nil, fmt.Errorf("hkdf read failed: %w", err)
+	}
+	if _, err := io.ReadFull(r, workerSeed); err != nil {
+		return nil, nil, nil, fmt.Errorf("hkdf read failed: %w", err)
+	}
+
+	if owner, err = key.NewKey(types.KeyInfo{Type: types.KTSecp256k1, PrivateKey: ownerPK}); err != nil {
+		return nil, nil, nil, fmt.Errorf("owner key: %w", err)
+	}
+	// The power actor only accepts BLS worker keys
+	workerPK, err := bls.PrivateKeyFromSeed(workerSeed)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("worker bls key: %w", err)
+	}
+	if worker, err = key.NewKey(types.KeyInfo{Type: types.KTBLS, PrivateKey: workerPK}); err != nil {
+		return nil, nil, nil, fmt.Errorf("worker key: %w", err)
+	}
+	if peerKey, _, err = crypto.GenerateEd25519Key(r); err != nil {
+		return nil, nil, nil, fmt.Errorf("peer key: %w", err)
+	}
+	return owner, worker, peerKey, nil
+}
+
+// generateMiners writes miners.json and returns the funded owner and worker
+// accounts for genesis_allocs.json; the owner pays the miner creation deposit
+// at genesis.
+func generateMiners(count int, outDir string, balance string, seed string, sectorSize uint64) ([]GenesisAccount, error) {
+	log.Printf("Generating %d genesis miners (sector size %d)...", count, sectorSize)
+
+	var genesisAccs []GenesisAccount
+	var miners []MinerEntry
+	for i := 0; i < count; i++ {
+		owner, worker, peerKey, err := deriveMinerKeys(seed, i)
+		if err != nil {
+			return nil, fmt.Errorf("failed to derive miner %d: %w", i, err)
+		}
+		pid, err := peer.IDFromPrivateKey(peerKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to derive peer id %d: %w", i, err)
+		}
+		peerKeyBytes, err := crypto.MarshalPrivateKey(peerKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal peer key %d: %w", i, err)
+		}
+
+		for _, k := range []*key.Key{owner, worker} {
+			genesisAccs = append(genesisAccs, GenesisAccount{
+				Type:    "account",
+				Balance: balance,
+				Meta: struct {
+					Owner string `json:"Owner"`
+				}{Owner: k.Address.String()},
+			})
+		}
+		miners = append(miners, MinerEntry{
+			Owner:      owner.Address.String(),
+			OwnerKey:   hex.EncodeToString(owner.KeyInfo.PrivateKey),
+			Worker:     worker.Address.String(),
+			WorkerKey:  hex.EncodeToString(worker.KeyInfo.PrivateKey),
+			PeerId:     pid.String(),
+			PeerKey:    hex.EncodeToString(peerKeyBytes),
+			SectorSize: sectorSize,
+		})
+	}
+
+	if err := writeJson(fmt.Sprintf("%s/miners.json", outDir), miners); err != nil {
+		return nil, err
+	}
+	return genesisAccs, nil
+}
+
 func writeJson(path string, data interface{}) error {
 	b, _ := json.MarshalIndent(data, "", "  ")
 	return os.WriteFile(path, b, 0644)
@@ -0,0 +1,176 @@
+package main
+
+import (
+	"log"
+	"sort"
+	"sync"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/lotus/chain/types"
+	"github.com/ipfs/go-cid"
+)
+
+// ===========================================================================
+// Per-vector gas accounting
+//
+// Messages submitted through the shared push helpers are tagged with the
+// vector that was running when they were sent. resolveGasAccounting later
+// looks up their receipts and sums GasUsed per vector, revealing which
+// vectors actually drive FVM load versus which mostly skip. Resolved
+// messages also feed the recentExecuted ring that DoStateReplay samples.
+// ===========================================================================
+
+const (
+	maxTrackedGasMsgs    = 1000 // bound on unresolved tagged messages
+	gasResolveInterval   = 50   // main-loop iterations between resolution passes
+	gasResolveLookback   = 200  // StateSearchMsg lookback in epochs
+	gasResolveMaxPerPass = 100  // StateSearchMsg calls per resolution pass
+)
+
+type trackedGasMsg struct {
+	msgCid cid.Cid
+	vector string
+	epoch  abi.ChainEpoch // head when first seen by a resolution pass; 0 until then
+}
+
+var (
+	// currentVector is the name of the action the main loop is running.
+	// Written only by the main loop between actions.
+	currentVector string
+
+	gasMu          sync.Mutex
+	trackedGasMsgs []trackedGasMsg
+	gasByVector    = make(map[string]int64)
+	gasMsgsDropped int
+
+	// gasResolveOffset rotates which node serves each lookup across
+	// passes. Main-goroutine only.
+	gasResolveOffset int
+)
+
+// recentExecutedCap bounds recentExecuted.
+const recentExecutedCap = 100
+
+// executedMsg is a tracked message whose receipt has been found.
+type executedMsg struct {
+	msgCid cid.Cid
+	vector string
+	height abi.ChainEpoch
+}
+
+// recentExecuted is a ring of the latest resolved messages, sampled by
+// DoStateReplay. Main-goroutine only.
+var (
+	recentExecuted    []executedMsg
+	recentExecutedPos int
+)
+
+// recordExecuted adds m to the recentExecuted ring.
+func recordExecuted(m executedMsg) {
+	if len(recentExecuted) < recentExecutedCap {
+		recentExecuted = append(recentExecuted, m)
+		return
+	}
+	recentExecuted[recentExecutedPos] = m
+	recentExecutedPos = (recentExecutedPos + 1) % recentExecutedCap
+}
+
+// trackMsgGas tags a submitted message with the currently running vector.
+func trackMsgGas(msgCid cid.Cid) {
+	gasMu.Lock()
+	defer gasMu.Unlock()
+	if len(trackedGasMsgs) >= maxTrackedGasMsgs {
+		gasMsgsDropped++
+		return
+	}
+	trackedGasMsgs = append(trackedGasMsgs, trackedGasMsg{msgCid: msgCid, vector: currentVector})
+}
+
+// resolveGasAccounting searches for receipts of tracked messages and adds
+// their GasUsed to the originating vector. Each pass looks up at most
+// gasResolveMaxPerPass messages, spread round-robin across nodes; the rest
+// wait for later passes. Messages still unfound once they are older than
+// the search lookback can no longer be resolved and are dropped.
+func resolveGasAccounting() {
+	gasMu.Lock()
+	pending := trackedGasMsgs
+	trackedGasMsgs = nil
+	gasMu.Unlock()
+
+	if len(pending) == 0 {
+		return
+	}
+
+	head, err := nodes[nodeKeys[0]].ChainHead(ctx)
+	if err != nil {
+		log.Printf("[gas-accounting] ChainHead failed: %v", err)
+		gasMu.Lock()
+		trackedGasMsgs = append(pending, trackedGasMsgs...)
+		gasMu.Unlock()
+		return
+	}
+
+	var remaining []trackedGasMsg
+	resolved := make(map[string]int64)
+	looked, found, expired := 0, 0, 0
+	for _, m := range pending {
+		if m.epoch == 0 {
+			m.epoch = head.Height()
+		}
+		if looked >= gasResolveMaxPerPass {
+			remaining = append(remaining, m)
+			continue
+		}
+		node := nodes[nodeKeys[(gasResolveOffset+looked)%len(nodeKeys)]]
+		looked++
+		lookup, err := node.StateSearchMsg(ctx, types.EmptyTSK, m.msgCid, gasResolveLookback, true)
+		if err != nil || lookup == nil {
+			if head.Height()-m.epoch > gasResolveLookback {
+				expired++
+				continue
+			}
+			remaining = append(remaining, m)
+			continue
+		}
+		found++
+		resolved[m.vector] += lookup.Receipt.GasUsed
+		recordExecuted(executedMsg{msgCid: m.msgCid, vector: m.vector, height: lookup.Height})
+	}
+	gasResolveOffset += looked
+
+	gasMu.Lock()
+	for vector, gas := range resolved {
+		gasByVector[vector] += gas
+	}
+	gasMsgsDropped += expired
+	trackedGasMsgs = append(remaining, trackedGasMsgs...)
+	gasMu.Unlock()
+
+	if expired > 0 {
+		log.Printf("[gas-accounting] dropped %d tracked messages unfound after %d epochs", expired, gasResolveLookback)
+	}
+	debugLog("  [gas-accounting] resolved %d/%d looked up (%d tracked)", found, looked, len(pending))
+}
+
+// snapshotGasByVector returns a copy of the per-vector gas totals.
+func snapshotGasByVector() map[string]int64 {
+	gasMu.Lock()
+	defer gasMu.Unlock()
+	out := make(map[string]int64, len(gasByVector))
+	for vector, gas := range gasByVector {
+		out[vector] = gas
+	}
+	return out
+}
+
+// logGasByVector prints per-vector gas totals in sorted order.
+func logGasByVector(gas map[string]int64) {
+	vectors := make([]string, 0, len(gas))
+	for vector := range gas {
+		vectors = append(vectors, vector)
+	}
+	sort.Strings(vectors)
+	for _, vector := range vectors {
+		log.Printf("[engine]   gas %s: %d", vector, gas[vector])
+	}
+}
@@ -0,0 +1,311 @@
+package main
+
+import "log"
+
+// ===========================================================================
+// Assertion IDs
+//
+// Every Antithesis assertion message is declared here, grouped by the vector
+// that raises it. IDs follow "<vector>: <property>", where <vector> is the
+// log tag the vector prints, so a finding maps straight back to its source.
+// Call sites pass the constant, never a string literal; each constant is
+// used by exactly one assert call.
+// ===========================================================================
+
+const (
+	// heavy-compute
+	aidHeavyComputeRootMatches = "heavy-compute: Recomputed state root matches stored state"
+
+	// chain-monitor
+	aidTipsetConsensus      = "chain-monitor: All nodes agree on the same finalized tipset"
+	aidHeightProgression    = "chain-monitor: Node chain heights are within acceptable range"
+	aidPeerCount            = "chain-monitor: Node has active peer connections"
+	aidHeadComparison       = "chain-monitor: Nodes at the same height agree on the same tipset"
+	aidStateRootConsistent  = "chain-monitor: Chain state is consistent across all nodes"
+	aidStateAuditRoot       = "chain-monitor: State root is consistent after FVM execution"
+	aidStateAuditMessages   = "chain-monitor: Parent messages match across nodes"
+	aidStateAuditReceipts   = "chain-monitor: Parent receipts match across nodes"
+	aidStateAuditCounts     = "chain-monitor: Message and receipt counts match"
+	aidChainIDConfigured    = "chain-monitor: Node reports the configured eth chain id"
+	aidNetVersionMatch      = "chain-monitor: All nodes report the same net_version"
+	aidGenesisMatch         = "chain-monitor: All nodes share the same genesis"
+	aidFinalityNoRegression = "chain-monitor: Previously finalized tipset stays canonical"
+
+	// null-round
+	aidNullRoundsAgree = "null-round: All nodes agree on null-round heights"
+
+	// burn-check
+	aidBurnMonotonic  = "burn-check: Burnt funds balance never decreases"
+	aidBurnConsistent = "burn-check: Burnt funds balance is consistent across nodes"
+
+	// read-storm
+	aidReadStormConsistent = "read-storm: Concurrent reads of an actor at one tipset return the same state"
+	aidReadStormServed     = "read-storm: Node serves a concurrent actor read storm without errors"
+
+	// block-msgs
+	aidBlockMsgsIdentical   = "block-msgs: Block messages are identical across nodes"
+	aidBlockMsgsSubsequence = "block-msgs: Tipset messages are an ordered subset of its blocks' messages"
+
+	// state-replay
+	aidStateReplayIdentical = "state-replay: Replaying a message yields identical gas used, exit code and return on two nodes"
+
+	// msg-order
+	aidMsgOrderIdentical = "msg-order: Two nodes return the same parent messages in the same order for a finalized block"
+
+	// balance-drift
+	aidBalanceDrift = "balance-drift: Wallet balance change matches its confirmed transfers and gas"
+
+	// state-content
+	aidStateContentHash      = "state-content: Actor state bytes hash to their CID"
+	aidStateContentIdentical = "state-content: Actor state bytes are identical across nodes"
+
+	// actor-diff
+	aidActorStateMatch = "actor-diff: Actor code, head, nonce and balance match between two nodes"
+
+	// restart-check
+	aidRestartRecovered = "restart-check: Restarted node's finalized chain matches the network"
+
+	// market-balance
+	aidMarketBalanceMatch = "market-balance: Market escrow and locked balances match across nodes"
+
+	// eth-block
+	aidEthBlockAgree = "eth-block: All nodes return the same eth block hash, transactions, gas used and logs bloom"
+
+	// eth-balance
+	aidEthBalanceMatch = "eth-balance: EthGetBalance matches the StateGetActor balance of the same actor"
+
+	// gas-estimate
+	aidGasEstimateWithinTolerance = "gas-estimate: Gas estimates for the same message agree across nodes within tolerance"
+
+	// f3-monitor
+	aidF3CertificateAgree = "f3-monitor: All nodes hold the same finality certificate for an instance"
+	aidF3Advancing        = "f3-monitor: F3 instance advances between checks"
+
+	// deploy
+	aidDeployCreateRedeployFails = "deploy: Same-nonce EAM.Create redeploy fails identically on every node"
+
+	// contract-call
+	aidContractCallSucceeds            = "contract-call: Contract call within safe depth succeeds"
+	aidContractCallOverflowFails       = "contract-call: Contract call past the call-stack limit fails"
+	aidContractCallRevertDeterministic = "contract-call: Failed contract call has the same exit code on every node"
+
+	// simplecoin-monitor
+	aidSimpleCoinConserved = "simplecoin-monitor: SimpleCoin balances sum to the minted supply"
+
+	// selfdestruct
+	aidSelfDestructConsistent = "selfdestruct: Actor state is consistent after self-destruct"
+	aidSelfDestructSwept      = "selfdestruct: Beneficiary receives exactly the destroyed contract's balance"
+	aidSelfDestructSweepAgree = "selfdestruct: All nodes agree on the funds swept by self-destruct"
+
+	// selfdestruct-recreate
+	aidRecreateSameAddress  = "selfdestruct-recreate: Recreated contract lands at its original CREATE2 address"
+	aidRecreateCodeRestored = "selfdestruct-recreate: Recreated contract serves its original runtime code"
+	aidRecreateStateAgree   = "selfdestruct-recreate: All nodes agree on the redeploy outcome and recreated actor state"
+
+	// deploy-fanin
+	aidFanInDistinctIDs   = "deploy-fanin: Concurrent deploys are allocated distinct actor IDs"
+	aidFanInAllSeen       = "deploy-fanin: Every node sees every concurrently deployed contract at the finalized tipset"
+	aidFanInIdenticalCode = "deploy-fanin: Concurrently deployed contracts have identical code on every node"
+
+	// create2-spam
+	aidCreate2AddressPredicted = "create2-spam: CREATE2 child lands at the predicted address"
+	aidCreate2CollisionReverts = "create2-spam: Repeated CREATE2 salt reverts identically on every node"
+
+	// revert-reason
+	aidRevertReasonDecoded       = "revert-reason: Revert data decodes to the reason the contract reverted with"
+	aidRevertReasonDeterministic = "revert-reason: All nodes return identical revert data"
+
+	// reentrancy
+	aidReentrancyBankSolvent    = "reentrancy: Bank balance equals its ledger total"
+	aidReentrancyNoFundsCreated = "reentrancy: Attacker balance equals the value it was paid"
+	aidReentrancyDrained        = "reentrancy: Attacker re-enters and drains more than it staked"
+	aidReentrancyStateAgree     = "reentrancy: All nodes read the same bank and attacker state"
+
+	// block-gas-limit
+	aidBlockGasLimitRejected = "block-gas-limit: Node rejects a message whose gas limit exceeds the block gas limit"
+
+	// log-blaster
+	aidLogBlasterReceiptLogs = "log-blaster: Eth receipt carries every emitted log"
+
+	// log-filter
+	aidLogFilterComplete = "log-filter: EthGetLogs returns every log the filtered tx emitted"
+	aidLogFilterMatches  = "log-filter: Every log EthGetLogs returns satisfies the filter"
+	aidLogFilterAgree    = "log-filter: Two nodes return identical EthGetLogs results"
+
+	// storage-spam
+	aidStorageSpamSlotPersisted = "storage-spam: Written storage slot holds the value the contract stored"
+	aidStorageSpamSlotAgree     = "storage-spam: Written storage slot reads the same on two nodes"
+
+	// state-growth
+	aidStateGrowthProportional = "state-growth: State growth stays proportional to issued storage writes"
+
+	// gas-war
+	aidGasWarReplaced  = "gas-war: Higher-premium replacement displaces the original in the mempool"
+	aidGasWarOneLanded = "gas-war: Exactly one of a replaced message pair lands on chain"
+
+	// adversarial
+	aidInvalidSigRejected = "adversarial: Message with invalid signature was rejected"
+
+	// missing-actor
+	aidMissingActorConsistent = "missing-actor: Message to missing actor has consistent receipt across nodes"
+	aidMissingActorFails      = "missing-actor: Message to missing ID actor does not succeed"
+	aidMissingActorCreates    = "missing-actor: Send to new f1 address creates the account"
+
+	// delegated-send
+	aidDelegatedSendAccepted = "delegated-send: Native message from delegated f4 sender accepted"
+
+	// eth-tx-mapping
+	aidEthTxMapping = "eth-tx-mapping: Eth tx view and native message view describe the same execution"
+
+	// eth-tx-index
+	aidEthTxIndexMatches = "eth-tx-index: Eth receipt transactionIndex matches the tx position in its block"
+	aidEthTxIndexAgree   = "eth-tx-index: All nodes agree on eth tx block placement and index"
+
+	// mpool-leak
+	aidMpoolLeak = "mpool-leak: Included message is removed from the mempool"
+
+	// mixed-nonce
+	aidMixedNonceOneLands = "mixed-nonce: At most one of a same-nonce native message and eth tx lands"
+
+	// nonce-reconcile
+	aidNonceReconcileGap = "nonce-reconcile: Reconciliation finds a local nonce gap"
+
+	// fee-spike
+	aidFeeSpikeRose = "fee-spike: Base fee rises above the genesis floor under congestion"
+
+	// mempool-flood
+	aidMempoolFloodResponsive = "mempool-flood: Node answers MpoolPending after a nonce flood"
+	aidMempoolFloodBounded    = "mempool-flood: Mempool size stays bounded under a nonce flood"
+
+	// reorg
+	aidReorgCatchUp            = "reorg: Rejoined node catches up within a bound proportional to its gap"
+	aidReorgConnectivity       = "reorg: Network connectivity restored after reorg"
+	aidReorgStateConsistent    = "reorg: Chain state is consistent after reorg"
+	aidReorgHeights            = "reorg: Node heights within acceptable range after reorg"
+	aidReorgConverged          = "reorg: Nodes converged after reorg"
+	aidReorgStrandedTxSurvives = "reorg: Tx pushed to an isolated node lands or stays pending after the heal"
+	aidReorgDeepReverted       = "reorg: Out-mined victim reverts its deep fork and adopts the main chain"
+
+	// reorg-deploy
+	aidReorgDeployAtomic = "reorg-deploy: Deploy across reorg is fully applied or fully absent on all nodes"
+
+	// partition-matrix
+	aidPartitionConverged = "partition-matrix: Nodes converge after a partition-matrix heal"
+
+	// split-brain
+	aidSplitBrainConverged = "split-brain: All nodes converge to one finalized state root after a split-brain heal"
+
+	// rpc-fuzz
+	aidRPCFuzzError    = "rpc-fuzz: Node answers malformed JSON-RPC with a JSON-RPC error"
+	aidRPCFuzzNoResult = "rpc-fuzz: Malformed JSON-RPC request never yields a result"
+	aidRPCFuzzAlive    = "rpc-fuzz: Node serves RPC after malformed request"
+)
+
+// assertionIDs registers every ID above; checkAssertionIDs rejects duplicates.
+var assertionIDs = []string{
+	aidHeavyComputeRootMatches,
+	aidTipsetConsensus,
+	aidHeightProgression,
+	aidPeerCount,
+	aidHeadComparison,
+	aidStateRootConsistent,
+	aidStateAuditRoot,
+	aidStateAuditMessages,
+	aidStateAuditReceipts,
+	aidStateAuditCounts,
+	aidChainIDConfigured,
+	aidNetVersionMatch,
+	aidGenesisMatch,
+	aidFinalityNoRegression,
+	aidNullRoundsAgree,
+	aidBurnMonotonic,
+	aidBurnConsistent,
+	aidReadStormConsistent,
+	aidReadStormServed,
+	aidBlockMsgsIdentical,
+	aidBlockMsgsSubsequence,
+	aidStateReplayIdentical,
+	aidMsgOrderIdentical,
+	aidBalanceDrift,
+	aidStateContentHash,
+	aidStateContentIdentical,
+	aidActorStateMatch,
+	aidRestartRecovered,
+	aidMarketBalanceMatch,
+	aidEthBlockAgree,
+	aidEthBalanceMatch,
+	aidGasEstimateWithinTolerance,
+	aidF3CertificateAgree,
+	aidF3Advancing,
+	aidDeployCreateRedeployFails,
+	aidContractCallSucceeds,
+	aidContractCallOverflowFails,
+	aidContractCallRevertDeterministic,
+	aidSimpleCoinConserved,
+	aidSelfDestructConsistent,
+	aidSelfDestructSwept,
+	aidSelfDestructSweepAgree,
+	aidRecreateSameAddress,
+	aidRecreateCodeRestored,
+	aidRecreateStateAgree,
+	aidFanInDistinctIDs,
+	aidFanInAllSeen,
+	aidFanInIdenticalCode,
+	aidCreate2AddressPredicted,
+	aidCreate2CollisionReverts,
+	aidRevertReasonDecoded,
+	aidRevertReasonDeterministic,
+	aidReentrancyBankSolvent,
+	aidReentrancyNoFundsCreated,
+	aidReentrancyDrained,
+	aidReentrancyStateAgree,
+	aidBlockGasLimitRejected,
+	aidLogBlasterReceiptLogs,
+	aidLogFilterComplete,
+	aidLogFilterMatches,
+	aidLogFilterAgree,
+	aidStorageSpamSlotPersisted,
+	aidStorageSpamSlotAgree,
+	aidStateGrowthProportional,
+	aidGasWarReplaced,
+	aidGasWarOneLanded,
+	aidInvalidSigRejected,
+	aidMissingActorConsistent,
+	aidMissingActorFails,
+	aidMissingActorCreates,
+	aidDelegatedSendAccepted,
+	aidEthTxMapping,
+	aidEthTxIndexMatches,
+	aidEthTxIndexAgree,
+	aidMpoolLeak,
+	aidMixedNonceOneLands,
+	aidNonceReconcileGap,
+	aidFeeSpikeRose,
+	aidMempoolFloodResponsive,
+	aidMempoolFloodBounded,
+	aidReorgCatchUp,
+	aidReorgConnectivity,
+	aidReorgStateConsistent,
+	aidReorgHeights,
+	aidReorgConverged,
+	aidReorgStrandedTxSurvives,
+	aidReorgDeepReverted,
+	aidReorgDeployAtomic,
+	aidPartitionConverged,
+	aidSplitBrainConverged,
+	aidRPCFuzzError,
+	aidRPCFuzzNoResult,
+	aidRPCFuzzAlive,
+}
+
+// checkAssertionIDs fails fast if two registered IDs collide, which would
+// silently merge unrelated assertions in the Antithesis report.
+func checkAssertionIDs() {
+	seen := make(map[string]bool, len(assertionIDs))
+	for _, id := range assertionIDs {
+		if seen[id] {
+			log.Fatalf("[init] FATAL: duplicate assertion ID %q", id)
+		}
+		seen[id] = true
+	}
+}
@@ -0,0 +1,214 @@
+package main
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// parseEngine parses the non-test files of this package.
+func parseEngine(t *testing.T) (*token.FileSet, []*ast.File) {
+	t.Helper()
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, ".", func(fi fs.FileInfo) bool {
+		return !strings.HasSuffix(fi.Name(), "_test.go")
+	}, 0)
+	if err != nil {
+		t.Fatalf("parse package: %v", err)
+	}
+	pkg, ok := pkgs["main"]
+	if !ok {
+		t.Fatal("package main not found")
+	}
+	names := make([]string, 0, len(pkg.Files))
+	for name := range pkg.Files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	files := make([]*ast.File, 0, len(names))
+	for _, name := range names {
+		files = append(files, pkg.Files[name])
+	}
+	return fset, files
+}
+
+// assertWrappers maps each engine assertion wrapper to the SDK call it
+// forwards to.
+var assertWrappers = map[string]string{
+	"alwaysAssert":    "Always",
+	"sometimesAssert": "Sometimes",
+}
+
+// wrapperAssertCall returns the message argument of an alwaysAssert or
+// sometimesAssert call.
+func wrapperAssertCall(n ast.Node) (ast.Expr, bool) {
+	call, ok := n.(*ast.CallExpr)
+	if !ok {
+		return nil, false
+	}
+	fn, ok := call.Fun.(*ast.Ident)
+	if !ok || assertWrappers[fn.Name] == "" || len(call.Args) != 3 {
+		return nil, false
+	}
+	return call.Args[1], true
+}
+
+// isSDKAssertCall reports whether n calls the SDK's assert package directly.
+func isSDKAssertCall(n ast.Node) bool {
+	call, ok := n.(*ast.CallExpr)
+	if !ok {
+		return false
+	}
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return false
+	}
+	pkg, ok := sel.X.(*ast.Ident)
+	return ok && pkg.Name == "assert"
+}
+
+func aidName(e ast.Expr) (string, bool) {
+	id, ok := e.(*ast.Ident)
+	if !ok || !strings.HasPrefix(id.Name, "aid") {
+		return "", false
+	}
+	return id.Name, true
+}
+
+// TestAssertionCallSites checks every assertion passes an aid* constant,
+// each constant backs exactly one assertion, and the SDK is only called
+// from inside the wrappers, so the coverage tally and shutdown skip apply
+// to every assertion.
+func TestAssertionCallSites(t *testing.T) {
+	fset, files := parseEngine(t)
+
+	uses := make(map[string][]string) // aid -> call positions
+	for _, f := range files {
+		for _, decl := range f.Decls {
+			fn, isFunc := decl.(*ast.FuncDecl)
+			inWrapper := isFunc && fn.Recv == nil && assertWrappers[fn.Name.Name] != ""
+			ast.Inspect(decl, func(n ast.Node) bool {
+				if n == nil {
+					return false
+				}
+				pos := fset.Position(n.Pos()).String()
+				if isSDKAssertCall(n) && !inWrapper {
+					t.Errorf("%s: SDK assertion called directly; use alwaysAssert or sometimesAssert", pos)
+					return true
+				}
+				arg, ok := wrapperAssertCall(n)
+				if !ok {
+					return true
+				}
+				name, ok := aidName(arg)
+				if !ok {
+					t.Errorf("%s: assertion message is not an aid* constant", pos)
+					return true
+				}
+				uses[name] = append(uses[name], pos)
+				return true
+			})
+		}
+	}
+
+	for name, positions := range uses {
+		if len(positions) != 1 {
+			t.Errorf("%s used by %d assertions: %v", name, len(positions), positions)
+		}
+	}
+
+	for _, name := range declaredAssertionIDs(t, files) {
+		if len(uses[name]) == 0 {
+			t.Errorf("%s is declared but never asserted", name)
+		}
+	}
+}
+
+// declaredAssertionIDs returns the aid* constants declared in assertions.go.
+func declaredAssertionIDs(t *testing.T, files []*ast.File) []string {
+	t.Helper()
+	var names []string
+	for _, f := range files {
+		for _, decl := range f.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok || gen.Tok != token.CONST {
+				continue
+			}
+			for _, spec := range gen.Specs {
+				for _, ident := range spec.(*ast.ValueSpec).Names {
+					if strings.HasPrefix(ident.Name, "aid") {
+						names = append(names, ident.Name)
+					}
+				}
+			}
+		}
+	}
+	if len(names) == 0 {
+		t.Fatal("no aid* constants found")
+	}
+	return names
+}
+
+// TestAssertionIDRegistry checks the const block and the assertionIDs slice
+// list the same IDs, each once.
+func TestAssertionIDRegistry(t *testing.T) {
+	_, files := parseEngine(t)
+
+	declared := make(map[string]bool)
+	for _, name := range declaredAssertionIDs(t, files) {
+		declared[name] = true
+	}
+
+	var listed []string
+	for _, f := range files {
+		for _, decl := range f.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok || gen.Tok != token.VAR {
+				continue
+			}
+			for _, spec := range gen.Specs {
+				vs := spec.(*ast.ValueSpec)
+				if len(vs.Names) != 1 || vs.Names[0].Name != "assertionIDs" {
+					continue
+				}
+				lit := vs.Values[0].(*ast.CompositeLit)
+				for _, elt := range lit.Elts {
+					name, ok := aidName(elt)
+					if !ok {
+						t.Errorf("assertionIDs entry is not an aid* constant")
+						continue
+					}
+					listed = append(listed, name)
+				}
+			}
+		}
+	}
+
+	seen := make(map[string]bool, len(listed))
+	for _, name := range listed {
+		if seen[name] {
+			t.Errorf("%s listed twice in assertionIDs", name)
+		}
+		seen[name] = true
+		if !declared[name] {
+			t.Errorf("%s listed in assertionIDs but not declared", name)
+		}
+	}
+	for name := range declared {
+		if !seen[name] {
+			t.Errorf("%s declared but missing from assertionIDs", name)
+		}
+	}
+
+	values := make(map[string]bool, len(assertionIDs))
+	for _, id := range assertionIDs {
+		if values[id] {
+			t.Errorf("duplicate assertion message %q", id)
+		}
+		values[id] = true
+	}
+}
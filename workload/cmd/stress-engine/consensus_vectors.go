@@ -1,13 +1,23 @@
 package main
 
 import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
 	"log"
+	"math"
 	"sync"
+	"time"
 
-	"github.com/antithesishq/antithesis-sdk-go/assert"
-
+	"github.com/filecoin-project/go-address"
 	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/filecoin-project/go-state-types/builtin"
+	"github.com/filecoin-project/lotus/api"
 	"github.com/filecoin-project/lotus/chain/types"
+	"github.com/filecoin-project/lotus/chain/types/ethtypes"
 	"github.com/ipfs/go-cid"
 )
 
@@ -26,7 +36,10 @@ const (
 )
 
 func DoHeavyCompute() {
-	nodeName, node := pickNode()
+	nodeName, node, ok := pickNodeWith("StateCompute")
+	if !ok {
+		return
+	}
 
 	head, err := node.ChainHead(ctx)
 	if err != nil {
@@ -37,6 +50,7 @@ func DoHeavyCompute() {
 	if head.Height() < computeMinHeight {
 		return
 	}
+	defer beginPhase("heavy-compute", map[string]any{"node": nodeName, "head": head.Height()})()
 
 	startHeight := head.Height() - abi.ChainEpoch(computeStartOffset)
 	endHeight := head.Height() - abi.ChainEpoch(computeEndOffset)
@@ -70,7 +84,7 @@ func DoHeavyCompute() {
 
 		stateMatches := st.Root == checkTs.ParentState()
 
-		assert.Always(stateMatches, "Recomputed state root matches stored state", map[string]any{
+		alwaysAssert(stateMatches, aidHeavyComputeRootMatches, map[string]any{
 			"node":           nodeName,
 			"node_type":      nodeType(nodeName),
 			"exec_height":    parentTs.Height(),
@@ -96,13 +110,15 @@ func DoHeavyCompute() {
 // ===========================================================================
 // DoChainMonitor (Consensus & Node Health)
 //
-// Six sub-checks picked randomly per invocation:
+// Eight sub-checks picked randomly per invocation:
 //   1. Tipset consensus at a finalized height
 //   2. Height progression (all nodes advancing)
 //   3. Peer count (all nodes have peers)
 //   4. Chain head comparison (finalized tipsets)
 //   5. State root comparison at a finalized height
 //   6. State audit (state roots + msg/receipt verification)
+//   7. Chain id (eth_chainId / net_version agree with config)
+//   8. Genesis (all nodes booted from the same genesis)
 //
 // State-sensitive checks (1, 4, 5, 6) use ChainGetFinalizedTipSet so they
 // are safe during partition → reorg chaos.
@@ -129,8 +145,10 @@ func allNodesPastEpoch(minEpoch abi.ChainEpoch) bool {
 }
 
 func DoChainMonitor() {
-	subCheck := rngIntn(6)
-	checkNames := []string{"tipset-consensus", "height-progression", "peer-count", "head-comparison", "state-root-comparison", "state-audit"}
+	doFinalityRegressionCheck()
+
+	subCheck := rngIntn(8)
+	checkNames := []string{"tipset-consensus", "height-progression", "peer-count", "head-comparison", "state-root-comparison", "state-audit", "chain-id", "genesis"}
 	debugLog("  [chain-monitor] sub-check: %s", checkNames[subCheck])
 
 	switch subCheck {
@@ -146,6 +164,10 @@ func DoChainMonitor() {
 		doStateRootComparison()
 	case 5:
 		doStateAudit()
+	case 6:
+		doChainIDCheck()
+	case 7:
+		doGenesisCheck()
 	}
 }
 
@@ -156,7 +178,7 @@ func getFinalizedHeight() (abi.ChainEpoch, types.TipSetKey) {
 	var minTsk types.TipSetKey
 	first := true
 	for _, name := range nodeKeys {
-		ts, err := nodes[name].ChainGetFinalizedTipSet(ctx)
+		ts, err := finalizedTipSet(name)
 		if err != nil {
 			log.Printf("[chain-monitor] ChainGetFinalizedTipSet failed for %s: %v", name, err)
 			return 0, types.EmptyTSK
@@ -170,6 +192,25 @@ func getFinalizedHeight() (abi.ChainEpoch, types.TipSetKey) {
 	return minHeight, minTsk
 }
 
+// finalityPollInterval is how often waitFinalized re-reads finality.
+const finalityPollInterval = 5 * time.Second
+
+// waitFinalized polls getFinalizedHeight until every node has finalized h
+// or waitCtx ends. Returns the last finalized height seen and whether it
+// reached h.
+func waitFinalized(waitCtx context.Context, h abi.ChainEpoch) (abi.ChainEpoch, bool) {
+	finalizedHeight, _ := getFinalizedHeight()
+	for finalizedHeight < h {
+		select {
+		case <-waitCtx.Done():
+			return finalizedHeight, false
+		case <-time.After(finalityPollInterval):
+		}
+		finalizedHeight, _ = getFinalizedHeight()
+	}
+	return finalizedHeight, true
+}
+
 // doTipsetConsensus checks that all nodes agree on the tipset at a finalized height.
 func doTipsetConsensus() {
 	if len(nodeKeys) < 2 {
@@ -202,12 +243,12 @@ func doTipsetConsensus() {
 		go func(nodeName string) {
 			defer wg.Done()
 			// Use finalized tipset as the anchor for lookback
-			finTs, err := nodes[nodeName].ChainGetFinalizedTipSet(ctx)
+			finTs, err := finalizedTipSet(nodeName)
 			if err != nil {
 				results <- result{name: nodeName, err: err}
 				return
 			}
-			ts, err := nodes[nodeName].ChainGetTipSetByHeight(ctx, checkHeight, finTs.Key())
+			ts, err := tipSetByHeight(nodeName, checkHeight, finTs.Key())
 			if err != nil {
 				results <- result{name: nodeName, err: err}
 				return
@@ -236,14 +277,14 @@ func doTipsetConsensus() {
 
 	consensusReached := len(tipsetKeys) == 1 && errs == 0
 
-	assert.Always(consensusReached, "All nodes agree on the same finalized tipset", map[string]any{
+	alwaysAssert(consensusReached, aidTipsetConsensus, withDivergence(tipsetKeys, map[string]any{
 		"height":         checkHeight,
 		"finalized_at":   finalizedHeight,
 		"tipset_keys":    tipsetKeys,
 		"unique_tipsets": len(tipsetKeys),
 		"nodes_checked":  len(nodeKeys),
 		"errors":         errs,
-	})
+	}))
 }
 
 // doHeightProgression checks that all nodes are advancing.
@@ -251,7 +292,7 @@ func doTipsetConsensus() {
 func doHeightProgression() {
 	heights := make(map[string]abi.ChainEpoch)
 	for _, name := range nodeKeys {
-		finTs, err := nodes[name].ChainGetFinalizedTipSet(ctx)
+		finTs, err := finalizedTipSet(name)
 		if err != nil {
 			log.Printf("[chain-monitor] ChainGetFinalizedTipSet failed for %s: %v", name, err)
 			continue
@@ -289,7 +330,7 @@ func doHeightProgression() {
 	spread := maxH - minH
 	acceptable := spread <= 10
 
-	assert.Sometimes(acceptable, "Node chain heights are within acceptable range", map[string]any{
+	sometimesAssert(acceptable, aidHeightProgression, map[string]any{
 		"heights": heights,
 		"spread":  spread,
 		"min":     minH,
@@ -310,7 +351,7 @@ func doPeerCount() {
 
 		peerCount := len(peers)
 
-		assert.Sometimes(peerCount > 0, "Node has active peer connections", map[string]any{
+		sometimesAssert(peerCount > 0, aidPeerCount, map[string]any{
 			"node":       name,
 			"node_type":  nodeType(name),
 			"peer_count": peerCount,
@@ -336,7 +377,7 @@ func doHeadComparison() {
 
 	var heads []headInfo
 	for _, name := range nodeKeys {
-		head, err := nodes[name].ChainGetFinalizedTipSet(ctx)
+		head, err := finalizedTipSet(name)
 		if err != nil {
 			log.Printf("[chain-monitor] ChainHead failed for %s: %v", name, err)
 			continue
@@ -372,7 +413,7 @@ func doHeadComparison() {
 			}
 		}
 
-		assert.Always(allMatch, "Nodes at the same height agree on the same tipset", map[string]any{
+		alwaysAssert(allMatch, aidHeadComparison, map[string]any{
 			"height":     height,
 			"nodes":      len(group),
 			"keys_match": allMatch,
@@ -400,12 +441,12 @@ func doStateRootComparison() {
 	// Collect parent state roots from all nodes at this finalized height
 	stateRoots := make(map[string][]string) // root -> []nodeName
 	for _, name := range nodeKeys {
-		finTs, err := nodes[name].ChainGetFinalizedTipSet(ctx)
+		finTs, err := finalizedTipSet(name)
 		if err != nil {
 			log.Printf("[chain-monitor] ChainGetFinalizedTipSet failed for %s: %v", name, err)
 			return
 		}
-		ts, err := nodes[name].ChainGetTipSetByHeight(ctx, checkHeight, finTs.Key())
+		ts, err := tipSetByHeight(name, checkHeight, finTs.Key())
 		if err != nil {
 			log.Printf("[chain-monitor] ChainGetTipSetByHeight(%d) failed for %s: %v", checkHeight, name, err)
 			return
@@ -416,13 +457,13 @@ func doStateRootComparison() {
 
 	statesMatch := len(stateRoots) == 1
 
-	assert.Always(statesMatch, "Chain state is consistent across all nodes", map[string]any{
+	alwaysAssert(statesMatch, aidStateRootConsistent, withDivergence(stateRoots, map[string]any{
 		"height":        checkHeight,
 		"finalized_at":  finalizedHeight,
 		"state_roots":   stateRoots,
 		"unique_states": len(stateRoots),
 		"nodes_checked": len(nodeKeys),
-	})
+	}))
 
 	if statesMatch {
 		debugLog("  [chain-monitor] OK: all %d nodes agree at height %d (finalized=%d)", len(nodeKeys), checkHeight, finalizedHeight)
@@ -454,11 +495,11 @@ func doStateAudit() {
 	var tipsetCids []cid.Cid
 
 	for _, name := range nodeKeys {
-		finTs, err := nodes[name].ChainGetFinalizedTipSet(ctx)
+		finTs, err := finalizedTipSet(name)
 		if err != nil {
 			return
 		}
-		ts, err := nodes[name].ChainGetTipSetByHeight(ctx, checkHeight, finTs.Key())
+		ts, err := tipSetByHeight(name, checkHeight, finTs.Key())
 		if err != nil {
 			return
 		}
@@ -472,12 +513,12 @@ func doStateAudit() {
 
 	rootsMatch := len(stateRoots) == 1
 
-	assert.Always(rootsMatch, "State root is consistent after FVM execution", map[string]any{
+	alwaysAssert(rootsMatch, aidStateAuditRoot, withDivergence(stateRoots, map[string]any{
 		"height":        checkHeight,
 		"finalized_at":  finalizedHeight,
 		"unique_states": len(stateRoots),
 		"state_roots":   stateRoots,
-	})
+	}))
 
 	if !rootsMatch {
 		log.Printf("[chain-monitor] STATE ROOT DIVERGENCE at height %d: %v", checkHeight, stateRoots)
@@ -508,7 +549,7 @@ func doStateAudit() {
 		}
 
 		msgsMatch := len(msgsA) == len(msgsB)
-		assert.Always(msgsMatch, "Parent messages match across nodes", map[string]any{
+		alwaysAssert(msgsMatch, aidStateAuditMessages, map[string]any{
 			"height":  checkHeight,
 			"block":   blkCid.String()[:16],
 			"count_a": len(msgsA),
@@ -516,7 +557,7 @@ func doStateAudit() {
 		})
 
 		receiptsMatch := len(receiptsA) == len(receiptsB)
-		assert.Always(receiptsMatch, "Parent receipts match across nodes", map[string]any{
+		alwaysAssert(receiptsMatch, aidStateAuditReceipts, map[string]any{
 			"height":  checkHeight,
 			"block":   blkCid.String()[:16],
 			"count_a": len(receiptsA),
@@ -524,7 +565,7 @@ func doStateAudit() {
 		})
 
 		msgReceiptMatch := len(msgsA) == len(receiptsA)
-		assert.Always(msgReceiptMatch, "Message and receipt counts match", map[string]any{
+		alwaysAssert(msgReceiptMatch, aidStateAuditCounts, map[string]any{
 			"height":   checkHeight,
 			"block":    blkCid.String()[:16],
 			"msgs":     len(msgsA),
@@ -539,3 +580,1501 @@ func doStateAudit() {
 
 	debugLog("  [chain-monitor] OK: state-audit height %d, roots match, msgs/receipts consistent", checkHeight)
 }
+
+// doChainIDCheck asserts every node reports the configured EIP-155 chain id
+// via eth_chainId and the same net_version. A node with the wrong chain id
+// silently rejects every eth transaction signed for the devnet.
+func doChainIDCheck() {
+	chainIDs := make(map[string]uint64)
+	versions := make(map[string][]string) // net_version -> []nodeName
+	for _, name := range nodeKeys {
+		id, err := nodes[name].EthChainId(ctx)
+		if err != nil {
+			log.Printf("[chain-monitor] EthChainId failed for %s: %v", name, err)
+			continue
+		}
+		chainIDs[name] = uint64(id)
+
+		idMatches := uint64(id) == uint64(ethChainID)
+		alwaysAssert(idMatches, aidChainIDConfigured, map[string]any{
+			"node":      name,
+			"node_type": nodeType(name),
+			"chain_id":  uint64(id),
+			"expected":  ethChainID,
+		})
+
+		ver, err := nodes[name].NetVersion(ctx)
+		if err != nil {
+			log.Printf("[chain-monitor] NetVersion failed for %s: %v", name, err)
+			continue
+		}
+		versions[ver] = append(versions[ver], name)
+	}
+
+	if len(versions) == 0 {
+		return
+	}
+
+	versionsMatch := len(versions) == 1
+	alwaysAssert(versionsMatch, aidNetVersionMatch, withDivergence(versions, map[string]any{
+		"versions":  versions,
+		"chain_ids": chainIDs,
+	}))
+
+	if !versionsMatch {
+		log.Printf("[chain-monitor] NET VERSION MISMATCH: %v (chain ids %v)", versions, chainIDs)
+	}
+}
+
+// doGenesisCheck asserts every node reports the same genesis tipset. A node
+// booted from a different genesis never converges, and would otherwise show
+// up only as constant, confusing divergence in every other check. Also run
+// once at startup.
+func doGenesisCheck() {
+	genesis := make(map[string][]string) // genesis key -> []nodeName
+	for _, name := range nodeKeys {
+		gen, err := nodes[name].ChainGetGenesis(ctx)
+		if err != nil {
+			log.Printf("[chain-monitor] ChainGetGenesis failed for %s: %v", name, err)
+			continue
+		}
+		key := gen.Key().String()
+		genesis[key] = append(genesis[key], name)
+	}
+
+	if len(genesis) == 0 {
+		return
+	}
+
+	genesisMatch := len(genesis) == 1
+	alwaysAssert(genesisMatch, aidGenesisMatch, withDivergence(genesis, map[string]any{
+		"unique_genesis": len(genesis),
+		"genesis":        genesis,
+	}))
+
+	if !genesisMatch {
+		log.Printf("[chain-monitor] GENESIS MISMATCH: %v", genesis)
+		return
+	}
+
+	debugLog("  [chain-monitor] OK: %d nodes share genesis", len(nodeKeys))
+}
+
+// finalizedMark is a finalized tipset previously reported by a node.
+type finalizedMark struct {
+	height abi.ChainEpoch
+	tsk    types.TipSetKey
+}
+
+// maxFinalizedSeen records the highest finalized tipset observed per node.
+var maxFinalizedSeen = make(map[string]finalizedMark)
+
+// doFinalityRegressionCheck asserts the highest finalized tipset each node
+// has reported stays on its canonical chain. A revert of a finalized tipset
+// is the most severe consensus failure, and point-in-time cross-node
+// comparisons cannot see it. ChainGetFinalizedTipSet falls back to EC
+// finality (head - 900) when no F3 certificate is available, so a lower
+// reported height alone is not a regression and is not asserted on; the
+// recorded mark is still checked and kept until a higher one replaces it.
+// Runs on every DoChainMonitor invocation.
+func doFinalityRegressionCheck() {
+	for _, name := range nodeKeys {
+		ts, err := finalizedTipSet(name)
+		if err != nil {
+			continue
+		}
+		prev, seen := maxFinalizedSeen[name]
+		height := ts.Height()
+
+		if seen {
+			canon, err := tipSetByHeight(name, prev.height, types.EmptyTSK)
+			if err != nil {
+				continue
+			}
+			stillCanonical := canon.Key() == prev.tsk
+			alwaysAssert(stillCanonical, aidFinalityNoRegression, map[string]any{
+				"node":          name,
+				"node_type":     nodeType(name),
+				"finalized":     height,
+				"prev_height":   prev.height,
+				"prev_tsk":      prev.tsk.String(),
+				"canonical_tsk": canon.Key().String(),
+			})
+			if !stillCanonical {
+				log.Printf("[chain-monitor] FINALITY REGRESSION on %s: finalized %s at %d replaced by %s",
+					name, prev.tsk, prev.height, canon.Key())
+				continue
+			}
+			if height < prev.height {
+				debugLog("  [chain-monitor] %s finalized %d below previous %d (EC fallback?), keeping mark", name, height, prev.height)
+				continue
+			}
+		}
+		maxFinalizedSeen[name] = finalizedMark{height: height, tsk: ts.Key()}
+	}
+}
+
+// ===========================================================================
+// DoNullRoundCheck (Consensus — Null Round Agreement)
+//
+// Devnets produce null rounds (heights with no blocks). ChainGetTipSetByHeight
+// returns the nearest earlier tipset for a null height, so a returned height
+// below the requested one marks a null round. All nodes must agree on exactly
+// which heights in a finalized range were null.
+// ===========================================================================
+
+const nullRoundScanEpochs = 20 // heights scanned per invocation
+
+func DoNullRoundCheck() {
+	if len(nodeKeys) < 2 {
+		return
+	}
+	if !allNodesPastEpoch(f3MinEpoch) {
+		return
+	}
+
+	finalizedHeight, _ := getFinalizedHeight()
+	if finalizedHeight < finalizedMinHeight {
+		return
+	}
+
+	endHeight := finalizedHeight
+	startHeight := endHeight - nullRoundScanEpochs + 1
+	if startHeight < 1 {
+		startHeight = 1
+	}
+
+	nullRounds := make(map[string][]abi.ChainEpoch) // nodeName -> null heights
+	groups := make(map[string][]string)             // null-set string -> []nodeName
+	for _, name := range nodeKeys {
+		finTs, err := finalizedTipSet(name)
+		if err != nil {
+			log.Printf("[null-round] ChainGetFinalizedTipSet failed for %s: %v", name, err)
+			return
+		}
+
+		nulls := []abi.ChainEpoch{}
+		for h := startHeight; h <= endHeight; h++ {
+			ts, err := tipSetByHeight(name, h, finTs.Key())
+			if err != nil {
+				log.Printf("[null-round] ChainGetTipSetByHeight(%d) failed for %s: %v", h, name, err)
+				return
+			}
+			if ts.Height() < h {
+				nulls = append(nulls, h)
+			}
+		}
+		nullRounds[name] = nulls
+		key := fmt.Sprint(nulls)
+		groups[key] = append(groups[key], name)
+	}
+
+	agree := len(groups) == 1
+
+	alwaysAssert(agree, aidNullRoundsAgree, withDivergence(groups, map[string]any{
+		"start_height": startHeight,
+		"end_height":   endHeight,
+		"null_rounds":  nullRounds,
+		"unique_sets":  len(groups),
+	}))
+
+	if agree {
+		debugLog("  [null-round] OK: %d nodes agree on null rounds in [%d,%d]: %v",
+			len(nodeKeys), startHeight, endHeight, nullRounds[nodeKeys[0]])
+	} else {
+		log.Printf("[null-round] NULL ROUND DISAGREEMENT in [%d,%d]: %v", startHeight, endHeight, nullRounds)
+	}
+}
+
+// ===========================================================================
+// DoBaseFeeBurnCheck (Consensus — Fee Accounting)
+//
+// Every included message burns base fee into the burnt-funds actor (f099).
+// Over a finalized range, the burnt-funds balance must never decrease and
+// every node must report the same balance at each height.
+// ===========================================================================
+
+const burnCheckEpochs = 5 // finalized heights sampled per invocation
+
+func DoBaseFeeBurnCheck() {
+	if len(nodeKeys) < 2 {
+		return
+	}
+	if !allNodesPastEpoch(f3MinEpoch) {
+		return
+	}
+
+	finalizedHeight, _ := getFinalizedHeight()
+	if finalizedHeight < finalizedMinHeight {
+		return
+	}
+
+	startHeight := finalizedHeight - burnCheckEpochs + 1
+	if startHeight < 1 {
+		startHeight = 1
+	}
+
+	// balances[height][nodeName] = burnt-funds balance
+	balances := make(map[abi.ChainEpoch]map[string]string)
+	for _, name := range nodeKeys {
+		finTs, err := finalizedTipSet(name)
+		if err != nil {
+			log.Printf("[burn-check] ChainGetFinalizedTipSet failed for %s: %v", name, err)
+			return
+		}
+
+		var prev abi.TokenAmount
+		for h := startHeight; h <= finalizedHeight; h++ {
+			ts, err := tipSetByHeight(name, h, finTs.Key())
+			if err != nil {
+				log.Printf("[burn-check] ChainGetTipSetByHeight(%d) failed for %s: %v", h, name, err)
+				return
+			}
+			act, err := nodes[name].StateGetActor(ctx, builtin.BurntFundsActorAddr, ts.Key())
+			if err != nil {
+				log.Printf("[burn-check] StateGetActor(burnt-funds) failed for %s at %d: %v", name, h, err)
+				return
+			}
+
+			if h > startHeight {
+				monotonic := act.Balance.GreaterThanEqual(prev)
+				alwaysAssert(monotonic, aidBurnMonotonic, map[string]any{
+					"node":      name,
+					"node_type": nodeType(name),
+					"height":    h,
+					"previous":  prev.String(),
+					"current":   act.Balance.String(),
+				})
+				if !monotonic {
+					log.Printf("[burn-check] BURNT FUNDS DECREASED on %s at height %d: %s -> %s",
+						name, h, prev, act.Balance)
+				}
+			}
+			prev = act.Balance
+
+			if balances[h] == nil {
+				balances[h] = make(map[string]string)
+			}
+			balances[h][name] = act.Balance.String()
+		}
+	}
+
+	for h, perNode := range balances {
+		unique := make(map[string][]string)
+		for name, bal := range perNode {
+			unique[bal] = append(unique[bal], name)
+		}
+		agree := len(unique) == 1
+
+		alwaysAssert(agree, aidBurnConsistent, withDivergence(unique, map[string]any{
+			"height":       h,
+			"finalized_at": finalizedHeight,
+			"balances":     unique,
+		}))
+
+		if !agree {
+			log.Printf("[burn-check] BURNT FUNDS DIVERGENCE at height %d: %v", h, unique)
+		}
+	}
+
+	debugLog("  [burn-check] OK: burnt funds checked over [%d,%d] on %d nodes",
+		startHeight, finalizedHeight, len(nodeKeys))
+}
+
+// ===========================================================================
+// DoActorReadStorm (Resource Safety — Concurrent State Reads)
+//
+// Fires many concurrent StateGetActor calls at a single node, pinned to one
+// tipset, for a mix of system actors and deployed contracts. Every read of
+// the same actor at the same tipset must return the same state head — the
+// serialized vectors never exercise the node's state-store concurrency.
+// ===========================================================================
+
+const (
+	readStormReadsPerActor = 8 // concurrent reads issued per actor
+	readStormMaxContracts  = 8 // deployed contracts mixed into the storm
+)
+
+var readStormSystemActors = []address.Address{
+	builtin.SystemActorAddr,
+	builtin.InitActorAddr,
+	builtin.RewardActorAddr,
+	builtin.CronActorAddr,
+	builtin.StoragePowerActorAddr,
+	builtin.StorageMarketActorAddr,
+	builtin.VerifiedRegistryActorAddr,
+	builtin.BurntFundsActorAddr,
+}
+
+func DoActorReadStorm() {
+	nodeName, node := pickNode()
+
+	head, err := node.ChainHead(ctx)
+	if err != nil {
+		log.Printf("[read-storm] ChainHead failed for %s: %v", nodeName, err)
+		return
+	}
+	tsk := head.Key()
+	defer beginPhase("read-storm", map[string]any{"node": nodeName, "height": head.Height()})()
+
+	targets := append([]address.Address{}, readStormSystemActors...)
+	contractsMu.Lock()
+	contracts := append([]deployedContract{}, deployedContracts...)
+	contractsMu.Unlock()
+	for i := 0; i < len(contracts) && i < readStormMaxContracts; i++ {
+		j := i + rngIntn(len(contracts)-i)
+		contracts[i], contracts[j] = contracts[j], contracts[i]
+		targets = append(targets, contracts[i].addr)
+	}
+
+	type readResult struct {
+		head cid.Cid
+		err  error
+	}
+	results := make([][]readResult, len(targets))
+	for i := range results {
+		results[i] = make([]readResult, readStormReadsPerActor)
+	}
+
+	var wg sync.WaitGroup
+	for i, addr := range targets {
+		for j := 0; j < readStormReadsPerActor; j++ {
+			wg.Add(1)
+			go func(i, j int, addr address.Address) {
+				defer wg.Done()
+				act, err := node.StateGetActor(ctx, addr, tsk)
+				if err != nil {
+					results[i][j] = readResult{err: err}
+					return
+				}
+				results[i][j] = readResult{head: act.Head}
+			}(i, j, addr)
+		}
+	}
+	wg.Wait()
+
+	failed := 0
+	for i, addr := range targets {
+		heads := make(map[string][]string) // state head -> []"node#read"
+		for j, r := range results[i] {
+			if r.err != nil {
+				failed++
+				continue
+			}
+			key := r.head.String()
+			heads[key] = append(heads[key], fmt.Sprintf("%s#%d", nodeName, j))
+		}
+
+		consistent := len(heads) <= 1
+		alwaysAssert(consistent, aidReadStormConsistent, withDivergence(heads, map[string]any{
+			"node":      nodeName,
+			"node_type": nodeType(nodeName),
+			"actor":     addr.String(),
+			"height":    head.Height(),
+			"heads":     heads,
+		}))
+
+		if !consistent {
+			log.Printf("[read-storm] INCONSISTENT READS on %s for %s at height %d: %v",
+				nodeName, addr, head.Height(), heads)
+		}
+	}
+
+	total := len(targets) * readStormReadsPerActor
+	sometimesAssert(failed == 0, aidReadStormServed, map[string]any{
+		"node":   nodeName,
+		"reads":  total,
+		"failed": failed,
+	})
+
+	debugLog("  [read-storm] OK: %d reads over %d actors on %s (%d failed)",
+		total, len(targets), nodeName, failed)
+}
+
+// ===========================================================================
+// DoBlockMessagesConsistency (Consensus — Block vs Tipset Message Views)
+//
+// For a block in a finalized tipset, ChainGetBlockMessages must return the
+// same ordered BLS+secp message list on every node. The tipset-level view
+// (ChainGetParentMessages of a child block) is the de-duplicated union of
+// its blocks' messages in block order, minus any whose nonce was already
+// consumed, so it must be an in-order subsequence of that union.
+// ===========================================================================
+
+func DoBlockMessagesConsistency() {
+	if len(nodeKeys) < 2 {
+		return
+	}
+	if !allNodesPastEpoch(f3MinEpoch) {
+		return
+	}
+
+	finalizedHeight, finTsk := getFinalizedHeight()
+	if finalizedHeight < finalizedMinHeight {
+		return
+	}
+
+	refName := nodeKeys[0]
+	ref := nodes[refName]
+
+	// Pick a tipset with a child at or below the finalized height
+	checkHeight := abi.ChainEpoch(rngIntn(int(finalizedHeight)-1) + 1)
+	ts, err := tipSetByHeight(refName, checkHeight, finTsk)
+	if err != nil {
+		log.Printf("[block-msgs] ChainGetTipSetByHeight(%d) failed: %v", checkHeight, err)
+		return
+	}
+	var child *types.TipSet
+	for h := ts.Height() + 1; h <= finalizedHeight; h++ {
+		c, err := tipSetByHeight(refName, h, finTsk)
+		if err != nil {
+			log.Printf("[block-msgs] ChainGetTipSetByHeight(%d) failed: %v", h, err)
+			return
+		}
+		if c.Height() > ts.Height() {
+			child = c
+			break
+		}
+	}
+	if child == nil || child.Parents() != ts.Key() {
+		return
+	}
+
+	// Phase 1: per-block message lists agree across nodes
+	blk := rngChoice(ts.Cids())
+	lists := make(map[string][]string) // ordered cid list -> []nodeName
+	for _, name := range nodeKeys {
+		bm, err := nodes[name].ChainGetBlockMessages(ctx, blk)
+		if err != nil {
+			log.Printf("[block-msgs] ChainGetBlockMessages failed for %s: %v", name, err)
+			return
+		}
+		key := fmt.Sprint(bm.Cids)
+		lists[key] = append(lists[key], name)
+	}
+
+	listsMatch := len(lists) == 1
+	alwaysAssert(listsMatch, aidBlockMsgsIdentical, withDivergence(lists, map[string]any{
+		"height":       ts.Height(),
+		"block":        blk.String(),
+		"finalized_at": finalizedHeight,
+		"unique_lists": len(lists),
+	}))
+
+	if !listsMatch {
+		log.Printf("[block-msgs] BLOCK MESSAGE DIVERGENCE at height %d block %s: %v", ts.Height(), cidStr(blk), lists)
+		return
+	}
+
+	// Phase 2: the tipset view is an in-order subsequence of the block union
+	var union []cid.Cid
+	seen := make(map[cid.Cid]bool)
+	for _, b := range ts.Cids() {
+		bm, err := ref.ChainGetBlockMessages(ctx, b)
+		if err != nil {
+			log.Printf("[block-msgs] ChainGetBlockMessages failed for %s: %v", refName, err)
+			return
+		}
+		for _, c := range bm.Cids {
+			if !seen[c] {
+				seen[c] = true
+				union = append(union, c)
+			}
+		}
+	}
+
+	parentMsgs, err := ref.ChainGetParentMessages(ctx, child.Cids()[0])
+	if err != nil {
+		log.Printf("[block-msgs] ChainGetParentMessages failed for %s: %v", refName, err)
+		return
+	}
+
+	applied := make([]cid.Cid, len(parentMsgs))
+	for i, pm := range parentMsgs {
+		applied[i] = pm.Cid
+	}
+	subsequence := isSubsequence(applied, union)
+
+	alwaysAssert(subsequence, aidBlockMsgsSubsequence, map[string]any{
+		"node":         refName,
+		"height":       ts.Height(),
+		"child_height": child.Height(),
+		"blocks":       len(ts.Cids()),
+		"union":        len(union),
+		"parent_msgs":  len(parentMsgs),
+	})
+
+	if !subsequence {
+		log.Printf("[block-msgs] TIPSET/BLOCK MESSAGE MISMATCH at height %d: union=%d parent=%d",
+			ts.Height(), len(union), len(parentMsgs))
+		return
+	}
+
+	debugLog("  [block-msgs] OK: height %d, %d blocks, %d union msgs, %d applied",
+		ts.Height(), len(ts.Cids()), len(union), len(parentMsgs))
+}
+
+// isSubsequence reports whether sub appears in seq in order (not necessarily
+// contiguously).
+func isSubsequence(sub, seq []cid.Cid) bool {
+	i := 0
+	for _, c := range seq {
+		if i < len(sub) && sub[i] == c {
+			i++
+		}
+	}
+	return i == len(sub)
+}
+
+// ===========================================================================
+// DoWalletBalanceDrift (Accounting — Balance vs Confirmed Activity)
+//
+// For one engine wallet over a finalized window, sums the value it sent and
+// received in successfully executed messages and bounds the gas it paid by
+// each outgoing message's GasFeeCap × GasLimit. The node-reported balance
+// change must fall inside [net transfers − max gas, net transfers]. A spend
+// that never debits, or debits a different amount, is an accounting bug.
+// Only finalized state is read, so reorgs cannot introduce noise.
+// ===========================================================================
+
+const walletDriftEpochs = 10 // finalized epochs reconciled per invocation
+
+func DoWalletBalanceDrift() {
+	if !allNodesPastEpoch(f3MinEpoch) {
+		return
+	}
+	finalizedHeight, finTsk := getFinalizedHeight()
+	if finalizedHeight < finalizedMinHeight+walletDriftEpochs {
+		return
+	}
+
+	nodeName, node := pickNode()
+	wallet, _ := pickWallet()
+	walletID, err := node.StateLookupID(ctx, wallet, finTsk)
+	if err != nil {
+		debugLog("  [balance-drift] StateLookupID failed for %s: %v", wallet, err)
+		return
+	}
+	isWallet := func(a address.Address) bool { return a == wallet || a == walletID }
+
+	startHeight := finalizedHeight - walletDriftEpochs
+	startTs, err := tipSetByHeight(nodeName, startHeight, finTsk)
+	if err != nil {
+		log.Printf("[balance-drift] ChainGetTipSetByHeight(%d) failed: %v", startHeight, err)
+		return
+	}
+	endTs, err := node.ChainGetTipSet(ctx, finTsk)
+	if err != nil {
+		log.Printf("[balance-drift] ChainGetTipSet failed: %v", err)
+		return
+	}
+
+	// Each tipset's parent messages produced its parent state, so the
+	// messages of every tipset in (start, end] explain the balance change.
+	net := big.Zero()
+	maxGas := big.Zero()
+	msgCount := 0
+	for h := startTs.Height() + 1; h <= endTs.Height(); h++ {
+		ts, err := tipSetByHeight(nodeName, h, finTsk)
+		if err != nil {
+			log.Printf("[balance-drift] ChainGetTipSetByHeight(%d) failed: %v", h, err)
+			return
+		}
+		if ts.Height() < h {
+			continue // null round
+		}
+		msgs, err := node.ChainGetParentMessages(ctx, ts.Cids()[0])
+		if err != nil {
+			log.Printf("[balance-drift] ChainGetParentMessages failed at %d: %v", h, err)
+			return
+		}
+		receipts, err := node.ChainGetParentReceipts(ctx, ts.Cids()[0])
+		if err != nil || len(receipts) != len(msgs) {
+			log.Printf("[balance-drift] ChainGetParentReceipts failed at %d: %v", h, err)
+			return
+		}
+		for i, m := range msgs {
+			from, to := isWallet(m.Message.From), isWallet(m.Message.To)
+			if !from && !to {
+				continue
+			}
+			msgCount++
+			ok := receipts[i].ExitCode.IsSuccess()
+			if from {
+				maxGas = big.Add(maxGas, big.Mul(m.Message.GasFeeCap, big.NewInt(m.Message.GasLimit)))
+				if ok {
+					net = big.Sub(net, m.Message.Value)
+				}
+			}
+			if to && ok {
+				net = big.Add(net, m.Message.Value)
+			}
+		}
+	}
+
+	startAct, err := node.StateGetActor(ctx, walletID, startTs.Key())
+	if err != nil {
+		return
+	}
+	endAct, err := node.StateGetActor(ctx, walletID, endTs.Key())
+	if err != nil {
+		return
+	}
+	delta := big.Sub(endAct.Balance, startAct.Balance)
+
+	lower := big.Sub(net, maxGas)
+	inRange := delta.GreaterThanEqual(lower) && delta.LessThanEqual(net)
+
+	sometimesAssert(inRange, aidBalanceDrift, map[string]any{
+		"node":         nodeName,
+		"wallet":       wallet.String(),
+		"from_height":  startTs.Height(),
+		"to_height":    endTs.Height(),
+		"messages":     msgCount,
+		"delta":        delta.String(),
+		"net_transfer": net.String(),
+		"max_gas":      maxGas.String(),
+	})
+
+	if !inRange {
+		log.Printf("[balance-drift] %s balance moved %s over [%d,%d], expected within [%s,%s] (%d msgs)",
+			wallet, delta, startTs.Height(), endTs.Height(), lower, net, msgCount)
+		return
+	}
+
+	debugLog("  [balance-drift] OK: %s moved %s over %d msgs in [%d,%d]",
+		wallet, delta, msgCount, startTs.Height(), endTs.Height())
+}
+
+// ===========================================================================
+// DoStateContentCheck (Consensus — Byte-Level State Equality)
+//
+// Root comparison trusts that equal CIDs mean equal content. For a sampled
+// actor at the common finalized tipset, this reads the raw bytes of its
+// state head from every node via ChainReadObj, asserts they are identical,
+// and re-hashes them to confirm they really are the content of that CID.
+// ===========================================================================
+
+func DoStateContentCheck() {
+	if len(nodeKeys) < 2 {
+		return
+	}
+	if !allNodesPastEpoch(f3MinEpoch) {
+		return
+	}
+	finalizedHeight, finTsk := getFinalizedHeight()
+	if finalizedHeight < finalizedMinHeight {
+		return
+	}
+
+	targets := append([]address.Address{}, readStormSystemActors...)
+	for _, c := range getContractsByType(rngChoice(contractTypes)) {
+		targets = append(targets, c.addr)
+	}
+	actor := rngChoice(targets)
+
+	contents := make(map[string][]string) // sha256(bytes) -> []nodeName
+	heads := make(map[string][]string)    // head CID -> []nodeName
+	for _, name := range nodeKeys {
+		act, err := retryRPC(func() (*types.Actor, error) {
+			return nodes[name].StateGetActor(ctx, actor, finTsk)
+		})
+		if err != nil {
+			debugLog("  [state-content] StateGetActor(%s) failed for %s: %v", actor, name, err)
+			return
+		}
+		raw, err := retryRPC(func() ([]byte, error) {
+			return nodes[name].ChainReadObj(ctx, act.Head)
+		})
+		if err != nil {
+			log.Printf("[state-content] ChainReadObj(%s) failed for %s: %v", act.Head, name, err)
+			return
+		}
+
+		rehashed, err := act.Head.Prefix().Sum(raw)
+		selfConsistent := err == nil && rehashed == act.Head
+		alwaysAssert(selfConsistent, aidStateContentHash, map[string]any{
+			"node":      name,
+			"node_type": nodeType(name),
+			"actor":     actor.String(),
+			"head":      act.Head.String(),
+			"rehashed":  cidStr(rehashed),
+			"bytes":     len(raw),
+		})
+
+		sum := sha256.Sum256(raw)
+		key := hex.EncodeToString(sum[:])
+		contents[key] = append(contents[key], name)
+		heads[act.Head.String()] = append(heads[act.Head.String()], name)
+	}
+
+	identical := len(contents) == 1
+	alwaysAssert(identical, aidStateContentIdentical, withDivergence(contents, map[string]any{
+		"actor":        actor.String(),
+		"finalized_at": finalizedHeight,
+		"contents":     contents,
+		"heads":        heads,
+	}))
+
+	if !identical {
+		log.Printf("[state-content] STATE CONTENT DIVERGENCE for %s at %d: contents=%v heads=%v",
+			actor, finalizedHeight, contents, heads)
+		return
+	}
+
+	debugLog("  [state-content] OK: %s state identical on %d nodes at height %d",
+		actor, len(nodeKeys), finalizedHeight)
+}
+
+// ===========================================================================
+// DoRestartRecoveryCheck (Crash Recovery — State After Restart)
+//
+// Antithesis kills and restarts nodes, but there is no direct restart
+// signal. A node is suspected of having restarted when its RPC was
+// unreachable and came back, or when its head height went backwards. Once a
+// suspect's finalized height reaches the network's finalized height at the
+// time of suspicion, its tipset there must match every other node's —
+// exercising the datastore-replay and crash-recovery path.
+// ===========================================================================
+
+var (
+	lastSeenHead    = make(map[string]abi.ChainEpoch)
+	unreachable     = make(map[string]bool)
+	restartSuspects = make(map[string]abi.ChainEpoch) // node -> height it must recover to
+)
+
+func DoRestartRecoveryCheck() {
+	if len(nodeKeys) < 2 {
+		return
+	}
+
+	// Phase 1: detect restarts heuristically
+	for _, name := range nodeKeys {
+		head, err := nodes[name].ChainHead(ctx)
+		if err != nil {
+			unreachable[name] = true
+			continue
+		}
+		prev, seen := lastSeenHead[name]
+		wentBack := seen && head.Height() < prev
+		if _, already := restartSuspects[name]; !already && (wentBack || unreachable[name]) {
+			if target := othersFinalizedHeight(name); target > 0 {
+				restartSuspects[name] = target
+				log.Printf("[restart-check] %s suspected restart (head %d -> %d, was_unreachable=%v), recovery target %d",
+					name, prev, head.Height(), unreachable[name], target)
+			}
+		}
+		unreachable[name] = false
+		lastSeenHead[name] = head.Height()
+	}
+
+	// Phase 2: verify recovered suspects against the rest of the network
+	for suspect, target := range restartSuspects {
+		finTs, err := finalizedTipSet(suspect)
+		if err != nil || finTs.Height() < target {
+			continue // still recovering
+		}
+		delete(restartSuspects, suspect)
+
+		tipsets := make(map[string][]string) // tipset key -> []nodeName
+		for _, name := range nodeKeys {
+			anchor, err := finalizedTipSet(name)
+			if err != nil || anchor.Height() < target {
+				continue
+			}
+			ts, err := tipSetByHeight(name, target, anchor.Key())
+			if err != nil {
+				continue
+			}
+			key := ts.Key().String()
+			tipsets[key] = append(tipsets[key], name)
+		}
+		if len(tipsets) == 0 {
+			continue
+		}
+
+		recovered := len(tipsets) == 1
+		alwaysAssert(recovered, aidRestartRecovered, withDivergence(tipsets, map[string]any{
+			"node":      suspect,
+			"node_type": nodeType(suspect),
+			"height":    target,
+			"tipsets":   tipsets,
+		}))
+
+		if recovered {
+			log.Printf("[restart-check] OK: %s recovered to height %d consistently", suspect, target)
+		} else {
+			log.Printf("[restart-check] RECOVERY DIVERGENCE for %s at height %d: %v", suspect, target, tipsets)
+		}
+	}
+}
+
+// othersFinalizedHeight returns the highest finalized height among all nodes
+// except the excluded one, or 0 if none could be queried.
+func othersFinalizedHeight(exclude string) abi.ChainEpoch {
+	var maxH abi.ChainEpoch
+	for _, name := range nodeKeys {
+		if name == exclude {
+			continue
+		}
+		ts, err := finalizedTipSet(name)
+		if err == nil && ts.Height() > maxH {
+			maxH = ts.Height()
+		}
+	}
+	return maxH
+}
+
+// ===========================================================================
+// DoMarketBalanceConsistency (Accounting — Market Escrow)
+//
+// Even without deals the storage market actor tracks escrow and locked
+// balances per address. Reads StateMarketBalance for a sample of wallets,
+// plus the market actor's own balance, on every node at the finalized
+// height and asserts the figures match. Addresses with zero escrow and
+// locked are omitted so a dealless devnet compares only the actor total.
+// ===========================================================================
+
+const marketBalanceSample = 5 // wallets read per invocation
+
+func DoMarketBalanceConsistency() {
+	if len(nodeKeys) < 2 {
+		return
+	}
+	if !allNodesPastEpoch(f3MinEpoch) {
+		return
+	}
+	finalizedHeight, finTsk := getFinalizedHeight()
+	if finalizedHeight < finalizedMinHeight {
+		return
+	}
+
+	sample := make([]address.Address, 0, marketBalanceSample)
+	for i := 0; i < marketBalanceSample; i++ {
+		sample = append(sample, rngChoice(addrs))
+	}
+
+	views := make(map[string][]string) // escrow view -> []nodeName
+	for _, name := range nodeKeys {
+		if !nodeSupports(name, "StateMarketBalance") {
+			continue
+		}
+		market, err := retryRPC(func() (*types.Actor, error) {
+			return nodes[name].StateGetActor(ctx, builtin.StorageMarketActorAddr, finTsk)
+		})
+		if err != nil {
+			log.Printf("[market-balance] StateGetActor(market) failed for %s: %v", name, err)
+			return
+		}
+		view := "market=" + market.Balance.String()
+
+		for _, addr := range sample {
+			bal, err := retryRPC(func() (api.MarketBalance, error) {
+				return nodes[name].StateMarketBalance(ctx, addr, finTsk)
+			})
+			if err != nil {
+				debugLog("  [market-balance] StateMarketBalance(%s) failed for %s: %v", addr, name, err)
+				continue // unknown to the market on this implementation
+			}
+			if bal.Escrow.NilOrZero() && bal.Locked.NilOrZero() {
+				continue
+			}
+			view += fmt.Sprintf(" %s=%s/%s", addr, bal.Escrow, bal.Locked)
+		}
+		views[view] = append(views[view], name)
+	}
+	if len(views) == 0 {
+		return // no node serves StateMarketBalance
+	}
+
+	agree := len(views) == 1
+	alwaysAssert(agree, aidMarketBalanceMatch, withDivergence(views, map[string]any{
+		"finalized_at": finalizedHeight,
+		"wallets":      len(sample),
+		"views":        views,
+	}))
+
+	if !agree {
+		log.Printf("[market-balance] MARKET BALANCE DIVERGENCE at height %d: %v", finalizedHeight, views)
+		return
+	}
+	debugLog("  [market-balance] OK: %d nodes agree on market balances at %d", len(nodeKeys), finalizedHeight)
+}
+
+// ===========================================================================
+// DoGasEstimateAudit (Interop — Gas Estimation)
+//
+// Estimates gas for the same plain transfer (first wallet to second wallet,
+// 1 attoFIL) on every node against the finalized tipset. Lotus and Forest
+// must land within gasEstimateTolerancePct of each other: a node that
+// under-estimates produces messages the other implementation runs out of
+// gas on, so a tx accepted through one node fails through another.
+// ===========================================================================
+
+const gasEstimateTolerancePct = 5
+
+func DoGasEstimateAudit() {
+	if len(nodeKeys) < 2 || len(addrs) < 2 {
+		return
+	}
+	finalizedHeight, finTsk := getFinalizedHeight()
+	if finalizedHeight < finalizedMinHeight {
+		return
+	}
+
+	estimates := make(map[string]int64)
+	groups := make(map[string][]string) // gas limit -> []nodeName
+	for _, name := range nodeKeys {
+		msg := &types.Message{From: addrs[0], To: addrs[1], Value: abi.NewTokenAmount(1)}
+		est, err := nodes[name].GasEstimateMessageGas(ctx, msg, nil, finTsk)
+		if err != nil {
+			log.Printf("[gas-estimate] GasEstimateMessageGas failed for %s: %v", name, err)
+			continue
+		}
+		estimates[name] = est.GasLimit
+		key := fmt.Sprint(est.GasLimit)
+		groups[key] = append(groups[key], name)
+	}
+	if len(estimates) < 2 {
+		return
+	}
+
+	lo, hi := int64(math.MaxInt64), int64(0)
+	for _, g := range estimates {
+		lo, hi = min(lo, g), max(hi, g)
+	}
+	within := (hi-lo)*100 <= lo*gasEstimateTolerancePct
+
+	alwaysAssert(within, aidGasEstimateWithinTolerance, withDivergence(groups, map[string]any{
+		"from":          addrs[0].String(),
+		"to":            addrs[1].String(),
+		"finalized_at":  finalizedHeight,
+		"estimates":     estimates,
+		"min":           lo,
+		"max":           hi,
+		"tolerance_pct": gasEstimateTolerancePct,
+	}))
+
+	if !within {
+		log.Printf("[gas-estimate] DIVERGENCE at height %d: estimates=%v", finalizedHeight, estimates)
+		return
+	}
+	debugLog("  [gas-estimate] OK: %d nodes within %d%% (min=%d max=%d)", len(estimates), gasEstimateTolerancePct, lo, hi)
+}
+
+// ===========================================================================
+// DoF3Monitor (Consensus Integrity — Fast Finality Certificates)
+//
+// Everything else trusts ChainGetFinalizedTipSet; this vector checks F3
+// itself. Nodes reach new instances at slightly different times, so the
+// comparison uses the newest instance every node has certified: each node
+// must return the same finalized head (epoch and tipset key) for it. Across
+// invocations the latest instance must also keep advancing. Nodes whose F3
+// is not running yet are skipped.
+// ===========================================================================
+
+// lastF3Instance is the highest certificate instance seen so far.
+var lastF3Instance uint64
+
+func DoF3Monitor() {
+	if !allNodesPastEpoch(f3MinEpoch) {
+		return
+	}
+
+	latest := make(map[string]uint64)
+	for _, name := range nodeKeys {
+		cert, err := nodes[name].F3GetLatestCertificate(ctx)
+		if err != nil || cert == nil {
+			debugLog("  [f3-monitor] %s has no F3 certificate yet: %v", name, err)
+			continue
+		}
+		latest[name] = cert.GPBFTInstance
+	}
+	if len(latest) == 0 {
+		return
+	}
+
+	common, newest := uint64(math.MaxUint64), uint64(0)
+	for _, inst := range latest {
+		common, newest = min(common, inst), max(newest, inst)
+	}
+
+	sometimesAssert(newest > lastF3Instance, aidF3Advancing, map[string]any{
+		"previous": lastF3Instance,
+		"latest":   latest,
+	})
+	if newest <= lastF3Instance {
+		debugLog("  [f3-monitor] F3 instance not advancing: latest=%d previous=%d", newest, lastF3Instance)
+	}
+	lastF3Instance = max(lastF3Instance, newest)
+
+	if len(latest) < 2 {
+		return
+	}
+
+	heads := make(map[string][]string) // "epoch:tipset" -> []nodeName
+	for name := range latest {
+		cert, err := nodes[name].F3GetCertificate(ctx, common)
+		if err != nil || cert == nil || cert.ECChain.IsZero() {
+			log.Printf("[f3-monitor] F3GetCertificate(%d) failed for %s: %v", common, name, err)
+			return
+		}
+		head := cert.ECChain.Head()
+		key := fmt.Sprintf("%d:%x", head.Epoch, head.Key)
+		if tsk, err := types.TipSetKeyFromBytes(head.Key); err == nil {
+			key = fmt.Sprintf("%d:%s", head.Epoch, tsk)
+		}
+		heads[key] = append(heads[key], name)
+	}
+
+	agree := len(heads) == 1
+	alwaysAssert(agree, aidF3CertificateAgree, withDivergence(heads, map[string]any{
+		"instance": common,
+		"latest":   latest,
+		"heads":    heads,
+	}))
+
+	if !agree {
+		log.Printf("[f3-monitor] DIVERGENCE at instance %d: %v", common, heads)
+		return
+	}
+	debugLog("  [f3-monitor] OK: %d nodes agree on instance %d (newest %d)", len(latest), common, newest)
+}
+
+// ===========================================================================
+// DoActorStateDiff (Consensus — Divergence Localization)
+//
+// A state root mismatch says the nodes disagree somewhere in the tree, not
+// where. This vector picks one actor — a system actor or a deployed
+// contract — and compares it field by field on two nodes at the finalized
+// tipset, so a divergence is reported as "balance of f02 differs" rather
+// than as two opaque roots.
+// ===========================================================================
+
+func DoActorStateDiff() {
+	if len(nodeKeys) < 2 {
+		return
+	}
+	if !allNodesPastEpoch(f3MinEpoch) {
+		return
+	}
+	finalizedHeight, finTsk := getFinalizedHeight()
+	if finalizedHeight < finalizedMinHeight {
+		return
+	}
+
+	targets := append([]address.Address{}, readStormSystemActors...)
+	for _, c := range getContractsByType(rngChoice(contractTypes)) {
+		targets = append(targets, c.addr)
+	}
+	actor := rngChoice(targets)
+
+	nameA := rngChoice(nodeKeys)
+	nameB := rngChoice(nodeKeys)
+	for nameB == nameA {
+		nameB = rngChoice(nodeKeys)
+	}
+
+	var acts [2]*types.Actor
+	for i, name := range []string{nameA, nameB} {
+		act, err := retryRPC(func() (*types.Actor, error) {
+			return nodes[name].StateGetActor(ctx, actor, finTsk)
+		})
+		if err != nil {
+			debugLog("  [actor-diff] StateGetActor(%s) failed for %s: %v", actor, name, err)
+			return
+		}
+		acts[i] = act
+	}
+	a, b := acts[0], acts[1]
+
+	var differs []string
+	if a.Code != b.Code {
+		differs = append(differs, "code")
+	}
+	if a.Head != b.Head {
+		differs = append(differs, "head")
+	}
+	if a.Nonce != b.Nonce {
+		differs = append(differs, "nonce")
+	}
+	if !a.Balance.Equals(b.Balance) {
+		differs = append(differs, "balance")
+	}
+
+	match := len(differs) == 0
+	alwaysAssert(match, aidActorStateMatch, map[string]any{
+		"actor":        actor.String(),
+		"finalized_at": finalizedHeight,
+		"node_a":       nameA,
+		"node_a_impl":  nodeImpl(nameA),
+		"node_b":       nameB,
+		"node_b_impl":  nodeImpl(nameB),
+		"differs":      differs,
+		"code":         []string{a.Code.String(), b.Code.String()},
+		"head":         []string{a.Head.String(), b.Head.String()},
+		"nonce":        []uint64{a.Nonce, b.Nonce},
+		"balance":      []string{a.Balance.String(), b.Balance.String()},
+	})
+
+	if !match {
+		log.Printf("[actor-diff] DIVERGENCE for %s at %d between %s and %s: fields %v differ (head %s vs %s, nonce %d vs %d, balance %s vs %s)",
+			actor, finalizedHeight, nameA, nameB, differs, a.Head, b.Head, a.Nonce, b.Nonce, a.Balance, b.Balance)
+		return
+	}
+	debugLog("  [actor-diff] OK: %s identical on %s and %s at height %d", actor, nameA, nameB, finalizedHeight)
+}
+
+// ===========================================================================
+// DoEthBlockAudit (Interop — Eth Block Mapping)
+//
+// Tipset agreement does not imply Eth API agreement: the block hash, the
+// transaction list, gas used and the logs bloom are all derived by each
+// node's Eth translation layer. This vector fetches one finalized height
+// via EthGetBlockByNumber on every node and requires identical views.
+// ===========================================================================
+
+const ethBlockAuditEpochs = 10 // finalized heights eligible for sampling
+
+func DoEthBlockAudit() {
+	if len(nodeKeys) < 2 {
+		return
+	}
+	if !allNodesPastEpoch(f3MinEpoch) {
+		return
+	}
+	finalizedHeight, _ := getFinalizedHeight()
+	if finalizedHeight < finalizedMinHeight {
+		return
+	}
+
+	height := finalizedHeight - abi.ChainEpoch(rngIntn(ethBlockAuditEpochs))
+	blkNum := ethtypes.EthUint64(height).Hex()
+
+	views := make(map[string]string)    // nodeName -> block summary
+	groups := make(map[string][]string) // block summary -> []nodeName
+	for _, name := range nodeKeys {
+		blk, err := retryRPC(func() (ethtypes.EthBlock, error) {
+			return nodes[name].EthGetBlockByNumber(ctx, blkNum, false)
+		})
+		if err != nil {
+			// Null rounds have no eth block; nodes that cannot answer are
+			// left to the null-round and chain-monitor checks.
+			debugLog("  [eth-block] EthGetBlockByNumber(%d) failed for %s: %v", height, name, err)
+			continue
+		}
+		view := fmt.Sprintf("hash=%s txs=%d gas_used=%d bloom=%s",
+			blk.Hash, len(blk.Transactions), blk.GasUsed, hex.EncodeToString(blk.LogsBloom))
+		views[name] = view
+		groups[view] = append(groups[view], name)
+	}
+	if len(views) < 2 {
+		return
+	}
+
+	agree := len(groups) == 1
+	alwaysAssert(agree, aidEthBlockAgree, withDivergence(groups, map[string]any{
+		"height":       height,
+		"finalized_at": finalizedHeight,
+		"views":        views,
+	}))
+
+	if !agree {
+		log.Printf("[eth-block] ETH BLOCK DIVERGENCE at height %d: %v", height, views)
+		return
+	}
+	debugLog("  [eth-block] OK: %d nodes agree on eth block %d", len(views), height)
+}
+
+// ===========================================================================
+// DoEthBalanceCheck (Interop — Eth vs Native Balance)
+//
+// The Eth API resolves an eth address (an f410 or a masked ID address) to an
+// actor and reports its balance; StateGetActor reads the same actor
+// natively. For a plain wallet, an EVM contract and, once one is funded, a
+// delegated f4 wallet, every node must report the same balance through both
+// surfaces at the finalized tipset. EthGetBalance reads the state after
+// executing a block and StateGetActor the state before it, so the eth side
+// is queried at the finalized tipset's parent.
+// ===========================================================================
+
+type ethBalanceTarget struct {
+	kind string // "account", "contract" or "delegated"
+	addr address.Address
+	eth  ethtypes.EthAddress
+}
+
+func DoEthBalanceCheck() {
+	finalizedHeight, finTsk := getFinalizedHeight()
+	if finalizedHeight < finalizedMinHeight {
+		return
+	}
+	_, node := pickNode()
+	targets := ethBalanceTargets(node, finTsk)
+
+	for _, name := range nodeKeys {
+		fin, err := nodes[name].ChainGetTipSet(ctx, finTsk)
+		if err != nil {
+			continue
+		}
+		parent, err := nodes[name].ChainGetTipSet(ctx, fin.Parents())
+		if err != nil {
+			continue
+		}
+		blk := ethtypes.NewEthBlockNumberOrHashFromNumber(ethtypes.EthUint64(parent.Height()))
+
+		for _, t := range targets {
+			act, err := retryRPC(func() (*types.Actor, error) {
+				return nodes[name].StateGetActor(ctx, t.addr, finTsk)
+			})
+			if err != nil {
+				debugLog("  [eth-balance] StateGetActor(%s) failed for %s: %v", t.addr, name, err)
+				continue
+			}
+			ethBal, err := retryRPC(func() (ethtypes.EthBigInt, error) {
+				return nodes[name].EthGetBalance(ctx, t.eth, blk)
+			})
+			if err != nil {
+				debugLog("  [eth-balance] EthGetBalance(%s) failed for %s: %v", t.eth, name, err)
+				continue
+			}
+
+			match := act.Balance.Equals(big.Int(ethBal))
+			alwaysAssert(match, aidEthBalanceMatch, map[string]any{
+				"node":          name,
+				"node_type":     nodeType(name),
+				"kind":          t.kind,
+				"address":       t.addr.String(),
+				"eth_address":   t.eth.String(),
+				"finalized_at":  finalizedHeight,
+				"parent_height": parent.Height(),
+				"native":        act.Balance.String(),
+				"eth":           big.Int(ethBal).String(),
+			})
+			if !match {
+				log.Printf("[eth-balance] BALANCE MISMATCH on %s for %s %s (%s) at %d: native=%s eth=%s",
+					name, t.kind, t.addr, t.eth, finalizedHeight, act.Balance, big.Int(ethBal))
+				continue
+			}
+			debugLog("  [eth-balance] OK: %s %s balance %s on %s", t.kind, t.addr, act.Balance, name)
+		}
+	}
+}
+
+// ethBalanceTargets resolves the eth addresses of one wallet, one deployed
+// contract and one funded delegated wallet, skipping any not available.
+func ethBalanceTargets(node api.FullNode, tsk types.TipSetKey) []ethBalanceTarget {
+	var targets []ethBalanceTarget
+
+	w := rngChoice(addrs)
+	if id, err := node.StateLookupID(ctx, w, tsk); err == nil {
+		if eth, err := ethtypes.EthAddressFromFilecoinAddress(id); err == nil {
+			targets = append(targets, ethBalanceTarget{kind: "account", addr: w, eth: eth})
+		}
+	}
+
+	if contracts := getContractsByType(rngChoice(contractTypes)); len(contracts) > 0 {
+		c := rngChoice(contracts)
+		act, err := node.StateGetActor(ctx, c.addr, tsk)
+		if err == nil && act.DelegatedAddress != nil {
+			if eth, err := ethtypes.EthAddressFromFilecoinAddress(*act.DelegatedAddress); err == nil {
+				targets = append(targets, ethBalanceTarget{kind: "contract", addr: c.addr, eth: eth})
+			}
+		}
+	}
+
+	var funded []*delegatedWallet
+	for _, dw := range delegatedWallets {
+		if dw.funded {
+			funded = append(funded, dw)
+		}
+	}
+	if len(funded) > 0 {
+		dw := rngChoice(funded)
+		if eth, err := ethtypes.EthAddressFromFilecoinAddress(dw.addr); err == nil {
+			targets = append(targets, ethBalanceTarget{kind: "delegated", addr: dw.addr, eth: eth})
+		}
+	}
+	return targets
+}
+
+// ===========================================================================
+// DoStateReplay (Consensus — Single-Message Determinism)
+//
+// StateReplay re-executes one message on top of its parent state. Picks a
+// recently executed message that is now final and replays it on two nodes:
+// gas used, exit code and return bytes must be identical. This isolates
+// per-message determinism from the whole-tipset StateCompute check. Nodes
+// without StateReplay are skipped via the capability gate.
+// ===========================================================================
+
+func DoStateReplay() {
+	var capable []string
+	for _, name := range nodeKeys {
+		if nodeSupports(name, "StateReplay") {
+			capable = append(capable, name)
+		}
+	}
+	if len(capable) < 2 {
+		return
+	}
+	finalizedHeight, _ := getFinalizedHeight()
+	if finalizedHeight < finalizedMinHeight {
+		return
+	}
+
+	var final []executedMsg
+	for _, m := range recentExecuted {
+		if m.height <= finalizedHeight {
+			final = append(final, m)
+		}
+	}
+	if len(final) == 0 {
+		return
+	}
+	m := rngChoice(final)
+
+	nameA := rngChoice(capable)
+	nameB := rngChoice(capable)
+	for nameB == nameA {
+		nameB = rngChoice(capable)
+	}
+
+	var res [2]*api.InvocResult
+	for i, name := range []string{nameA, nameB} {
+		r, err := retryRPC(func() (*api.InvocResult, error) {
+			return nodes[name].StateReplay(ctx, types.EmptyTSK, m.msgCid)
+		})
+		if err != nil || r == nil || r.MsgRct == nil {
+			debugLog("  [state-replay] StateReplay(%s) failed for %s: %v", cidStr(m.msgCid), name, err)
+			return
+		}
+		res[i] = r
+	}
+	a, b := res[0].MsgRct, res[1].MsgRct
+
+	var differs []string
+	if a.GasUsed != b.GasUsed {
+		differs = append(differs, "gas_used")
+	}
+	if a.ExitCode != b.ExitCode {
+		differs = append(differs, "exit_code")
+	}
+	if !bytes.Equal(a.Return, b.Return) {
+		differs = append(differs, "return")
+	}
+
+	identical := len(differs) == 0
+	alwaysAssert(identical, aidStateReplayIdentical, map[string]any{
+		"msg_cid":     m.msgCid.String(),
+		"vector":      m.vector,
+		"height":      m.height,
+		"node_a":      nameA,
+		"node_a_impl": nodeImpl(nameA),
+		"node_b":      nameB,
+		"node_b_impl": nodeImpl(nameB),
+		"differs":     differs,
+		"gas_used":    []int64{a.GasUsed, b.GasUsed},
+		"exit_code":   []string{a.ExitCode.String(), b.ExitCode.String()},
+		"return":      []string{hex.EncodeToString(a.Return), hex.EncodeToString(b.Return)},
+	})
+
+	if !identical {
+		log.Printf("[state-replay] REPLAY DIVERGENCE for %s (%s) between %s and %s: %v differ",
+			cidStr(m.msgCid), m.vector, nameA, nameB, differs)
+		return
+	}
+	debugLog("  [state-replay] OK: %s replays identically on %s and %s (gas=%d exit=%s)",
+		cidStr(m.msgCid), nameA, nameB, a.GasUsed, a.ExitCode)
+}
+
+// ===========================================================================
+// DoParentMessageOrder (Consensus — Message Ordering Determinism)
+//
+// Messages in a tipset execute in the order ChainGetParentMessages returns
+// them, so two nodes can hold the same message set and still compute
+// different states if they order it differently. doStateAudit compares only
+// counts; this vector compares the full ordered CID list for a finalized
+// block on two nodes and reports the first index where they differ.
+// ===========================================================================
+
+const msgOrderEpochs = 20 // finalized heights eligible for sampling
+
+func DoParentMessageOrder() {
+	if len(nodeKeys) < 2 {
+		return
+	}
+	if !allNodesPastEpoch(f3MinEpoch) {
+		return
+	}
+	finalizedHeight, finTsk := getFinalizedHeight()
+	if finalizedHeight < finalizedMinHeight {
+		return
+	}
+
+	nameA := rngChoice(nodeKeys)
+	nameB := rngChoice(nodeKeys)
+	for nameB == nameA {
+		nameB = rngChoice(nodeKeys)
+	}
+
+	height := finalizedHeight - abi.ChainEpoch(rngIntn(msgOrderEpochs))
+	ts, err := tipSetByHeight(nameA, height, finTsk)
+	if err != nil {
+		debugLog("  [msg-order] ChainGetTipSetByHeight(%d) failed for %s: %v", height, nameA, err)
+		return
+	}
+	blkCid := rngChoice(ts.Cids())
+
+	var orders [2][]cid.Cid
+	for i, name := range []string{nameA, nameB} {
+		msgs, err := retryRPC(func() ([]api.Message, error) {
+			return nodes[name].ChainGetParentMessages(ctx, blkCid)
+		})
+		if err != nil {
+			debugLog("  [msg-order] ChainGetParentMessages(%s) failed for %s: %v", blkCid, name, err)
+			return
+		}
+		for _, m := range msgs {
+			orders[i] = append(orders[i], m.Cid)
+		}
+	}
+	a, b := orders[0], orders[1]
+
+	firstDiff := -1
+	for i := 0; i < max(len(a), len(b)); i++ {
+		if i >= len(a) || i >= len(b) || a[i] != b[i] {
+			firstDiff = i
+			break
+		}
+	}
+
+	identical := firstDiff < 0
+	details := map[string]any{
+		"height":      ts.Height(),
+		"block":       blkCid.String(),
+		"node_a":      nameA,
+		"node_a_impl": nodeImpl(nameA),
+		"node_b":      nameB,
+		"node_b_impl": nodeImpl(nameB),
+		"count_a":     len(a),
+		"count_b":     len(b),
+		"first_diff":  firstDiff,
+	}
+	if !identical {
+		if firstDiff < len(a) {
+			details["cid_a"] = a[firstDiff].String()
+		}
+		if firstDiff < len(b) {
+			details["cid_b"] = b[firstDiff].String()
+		}
+	}
+	alwaysAssert(identical, aidMsgOrderIdentical, details)
+
+	if !identical {
+		log.Printf("[msg-order] ORDER DIVERGENCE for block %s at height %d between %s and %s: first difference at index %d (%d vs %d messages)",
+			blkCid, ts.Height(), nameA, nameB, firstDiff, len(a), len(b))
+		return
+	}
+	debugLog("  [msg-order] OK: %s and %s agree on %d ordered parent messages of %s", nameA, nameB, len(a), blkCid)
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"testing"
+
+	"workload/internal/chain/chaintest"
+)
+
+func TestStateRootComparisonAgreement(t *testing.T) {
+	useMockNodes(t, map[string]*chaintest.MockNode{
+		"lotus0": chaintest.NewMockNode(mockChain(20, "root")...),
+		"lotus1": chaintest.NewMockNode(mockChain(20, "root")...),
+	})
+
+	before := assertionTally(aidStateRootConsistent)
+	doStateRootComparison()
+	after := assertionTally(aidStateRootConsistent)
+
+	if after.Evaluated != before.Evaluated+1 || after.Passed != before.Passed+1 {
+		t.Fatalf("matching roots: tally went from %+v to %+v, want one passing evaluation", before, after)
+	}
+}
+
+func TestStateRootComparisonFlagsDivergence(t *testing.T) {
+	useMockNodes(t, map[string]*chaintest.MockNode{
+		"lotus0": chaintest.NewMockNode(mockChain(20, "root")...),
+		"lotus1": chaintest.NewMockNode(mockChain(20, "forked-root")...),
+	})
+
+	before := assertionTally(aidStateRootConsistent)
+	doStateRootComparison()
+	after := assertionTally(aidStateRootConsistent)
+
+	if after.Evaluated != before.Evaluated+1 || after.Passed != before.Passed {
+		t.Fatalf("divergent roots: tally went from %+v to %+v, want one failing evaluation", before, after)
+	}
+}
+
+func TestStateRootComparisonWaitsForFinality(t *testing.T) {
+	// Heads below f3MinEpoch: the check must not evaluate at all.
+	useMockNodes(t, map[string]*chaintest.MockNode{
+		"lotus0": chaintest.NewMockNode(mockChain(f3MinEpoch-1, "root")...),
+		"lotus1": chaintest.NewMockNode(mockChain(f3MinEpoch-1, "forked-root")...),
+	})
+
+	before := assertionTally(aidStateRootConsistent)
+	doStateRootComparison()
+	if after := assertionTally(aidStateRootConsistent); after != before {
+		t.Fatalf("young chain: tally went from %+v to %+v, want no evaluation", before, after)
+	}
+}
@@ -2,13 +2,17 @@ package main
 
 import (
 	"bytes"
+	"crypto/sha256"
 	"encoding/binary"
 	"encoding/hex"
+	"fmt"
 	"log"
+	"math/big"
 
 	"github.com/filecoin-project/go-address"
 	"github.com/filecoin-project/go-state-types/abi"
 	builtintypes "github.com/filecoin-project/go-state-types/builtin"
+	"github.com/filecoin-project/go-state-types/builtin/v15/eam"
 	"github.com/filecoin-project/lotus/api"
 	"github.com/filecoin-project/lotus/chain/actors"
 	"github.com/filecoin-project/lotus/chain/types"
@@ -50,20 +54,93 @@ var contractHex = map[string]string{
 
 	// StorageSpammer: spamSlots(uint256,uint256) — writes N unique storage slots per call
 	"storagespam": "6080604052348015600e575f5ffd5b506101758061001c5f395ff3fe608060405234801561000f575f5ffd5b5060043610610034575f3560e01c8063387dd9e9146100385780637af1a18314610069575b5f5ffd5b6100576100463660046100e3565b5f6020819052908152604090205481565b60405190815260200160405180910390f35b61007c6100773660046100fa565b61007e565b005b5f5b828110156100de5761009381600161011a565b5f5f83856040516020016100b1929190918252602082015260400190565b60408051601f198184030181529181528151602092830120835290820192909252015f2055600101610080565b505050565b5f602082840312156100f3575f5ffd5b5035919050565b5f5f6040838503121561010b575f5ffd5b50508035926020909101359150565b8082018082111561013957634e487b7160e01b5f52601160045260245ffd5b9291505056fea26469706673582212206ea170243d1d69348ab3f8a1ba8afcfb5f4ebdf67dce795f393fa4432810ca7764736f6c634300081e0033",
+
+	// --- Hand-assembled ---
+
+	// Create2Factory: deploy(bytes32 salt) — CREATE2s a one-byte child
+	// (initcode create2ChildInitcode) with the salt at calldata[4:36], emits
+	// Deployed(address) and returns the child; reverts if CREATE2 fails
+	"create2factory": "6055600c60003960556000f36c6001600c60003960016000f300600052600435600d60136000f5801561004f576020527ff40fcec21964ffb566044d083b4073f29f7f7929110ea19e1b3ebe375d89055e60206020a160206020f35b60006000fd",
+
+	// CreateFactory: relays calldata[4:] (the selector is ignored) as the
+	// input of the call_actor precompile (0xfe..05) via DELEGATECALL, so the
+	// factory itself calls an actor. Used to call EAM.Create with an explicit
+	// nonce, which the EAM only accepts from EVM actors. Returns the
+	// precompile output; reverts if the precompile fails or the called
+	// actor exits nonzero
+	"createfactory": "6042600c60003960426000f336600490038060046000376000600082600073fe000000000000000000000000000000000000055af43d600060003e15603d57600051603d573d6000f35b3d6000fd",
+
+	// RecreateFactory: deploy(bytes32 salt) — CREATE2s the SelfDestruct
+	// contract above (embedded verbatim as the child initcode) with the salt
+	// at calldata[4:36] and returns the child; reverts if CREATE2 fails, so a
+	// destroyed child can be recreated at the same address with the same salt
+	"recreatefactory": "60c6600c60003960c66000f360a2602460003960043560a260006000f58015601e5760005260206000f35b60006000fd6080604052348015600f57600080fd5b5060848061001e6000396000f3fe6080604052348015600f57600080fd5b506004361060285760003560e01c806383197ef014602d575b600080fd5b60336035565b005b3373ffffffffffffffffffffffffffffffffffffffff16fffea2646970667358221220d4aa109d42268586e7ce4f0fafb0ebbd04c412c6c7e8c387b009a08ecdff864264736f6c63430008110033",
+
+	// RevertReason: revertWithReason(string) — reverts with Error(string)
+	// revert data carrying the argument, built by swapping the selector in
+	// the calldata for 0x08c379a0; any other selector reverts empty
+	"revertreason": "6033600c60003960336000f336600060003760005160e01c63f7a3038114601a5760006000fd5b600860005360c3600153607960025360a0600353366000fd",
+
+	// ReentrancyBank: deposit() credits storage[caller] and the ledger total
+	// in slot 0; withdraw() sends storage[caller] back to the caller BEFORE
+	// zeroing it, so a re-entrant caller is paid repeatedly. Every successful
+	// payout also debits the total, so SELFBALANCE == slot 0 always holds
+	"reentrancybank": "6059600c60003960596000f360003560e01c8063d0e30db014601e57633ccfd60b14602e5760006000fd5b3433540133553460005401600055005b33548015605757600060006000600084335af1604a5760006000fd5b6000335560005403600055005b00",
+
+	// ReentrancyAttacker: attack(address bank) deposits msg.value into bank
+	// and withdraws it; the fallback re-enters withdraw() up to 4 times per
+	// attack. Slot 2 sums value received by the fallback (== balance), slot 3
+	// sums value staked through attack()
+	"reentrancyattacker": "60a1600c60003960a16000f36004361060695760003560e01c63d018db3e1415606957600435806000556000600155346003540160035563d0e30db060e01b600052600060006004600034855af115606357633ccfd60b60e01b60005260006000600460006000855af115606357005b60006000fd5b34600254016002556001548060041115609f57600101600155633ccfd60b60e01b600052600060006004600060006000545af150005b00",
+}
+
+// contractSHA256 pins the sha256 of each decoded bytecode in contractHex.
+// Update the entry whenever a contract is recompiled.
+var contractSHA256 = map[string]string{
+	"recursive":          "5dcf0843b84c7c17f5b3a8db0864eb68a6742ce166be9513dd41ccc9f97d9ef3",
+	"selfdestruct":       "a7f9d9a9d061d5c818dd688c2adf39eeecdeb057eb377f4089652956df837aa2",
+	"delegatecall":       "0af1f9893b90342f5ecccd475ce3f71309e87912c1d5d64227dc7f34e4561d0c",
+	"simplecoin":         "06967bf6936ef806ac0c7409570ae63ac992f5032b554d10cda9fa145220c7cb",
+	"extrecursive":       "efc69d5c6937238e100a7092053be9f5436ba7633e0f7e541507d4d86c816d71",
+	"gasguzzler":         "9db48684a7d3e05cbcc6ca6d5a655679a2713fef236f3b397e1d30c278d08869",
+	"logblaster":         "155050bab979866f90a77b7fcf4ccb352fac8edbc0ba2216846b4004b668e5dd",
+	"memorybomb":         "f58cde8f1b508fd3269c600cbd2317d27361c3b1ec8104cd9a547a41a0858949",
+	"storagespam":        "f493a631200e9f27ed63f783ed7492f64f75eb584b33d5642cf3de708f7a6e0c",
+	"create2factory":     "2a2db224dc5e18d097ef4ef364b3e91d883b392dd4e8b4e6a7fbc618a842d656",
+	"createfactory":      "46be2e88604a6573c46958f2e2c519e2c713422679b25e26b86ad2b28c1c9ee3",
+	"recreatefactory":    "3fc1ec4255f4e39115cf58b4348505c407247706766f7941dfd48146f5da0602",
+	"revertreason":       "8e91775dec27d0807b783d0ff2b08cee0552e704e84d59f5fad938744b9446b5",
+	"reentrancybank":     "2dce6ebe78478a82ce581753b11ee9505eaad15986abee4431cdc16274533bc9",
+	"reentrancyattacker": "e3a87691fbddbd8d4d93f41cf329a7a31c7cc4e4941e42bd417d59f3859793b4",
 }
 
 // ===========================================================================
 // Initialization
 // ===========================================================================
 
+// initContractBytecodes decodes every embedded contract and verifies it
+// against contractSHA256, failing fast on corruption rather than deploying
+// bytecode that reverts mysteriously at runtime.
 func initContractBytecodes() {
 	contractBytecodes = make(map[string][]byte, len(contractHex))
 	for name, hexStr := range contractHex {
 		b, err := hex.DecodeString(hexStr)
 		if err != nil {
-			log.Printf("[contracts] WARN: cannot decode hex for %s: %v", name, err)
-			continue
+			log.Fatalf("[contracts] FATAL: cannot decode hex for %s: %v", name, err)
+		}
+		if len(b) == 0 {
+			log.Fatalf("[contracts] FATAL: empty bytecode for %s", name)
 		}
+		sum := sha256.Sum256(b)
+		got := hex.EncodeToString(sum[:])
+		want, ok := contractSHA256[name]
+		if !ok {
+			log.Fatalf("[contracts] FATAL: no checksum recorded for %s (sha256=%s)", name, got)
+		}
+		if got != want {
+			log.Fatalf("[contracts] FATAL: checksum mismatch for %s: got %s, want %s", name, got, want)
+		}
+		log.Printf("[contracts] %s: %d bytes sha256=%s", name, len(b), got)
 		contractBytecodes[name] = b
 	}
 	contractTypes = make([]string, 0, len(contractBytecodes))
@@ -73,6 +150,63 @@ func initContractBytecodes() {
 	log.Printf("[contracts] loaded %d contract bytecodes", len(contractBytecodes))
 }
 
+// ===========================================================================
+// Call Magnitudes
+//
+// Every EVM vector draws its depth/iteration/count argument from this one
+// config so operators can dial FVM intensity up or down per run. Each range
+// is inclusive and overridable via <PREFIX>_MIN / <PREFIX>_MAX env vars.
+// ===========================================================================
+
+type magnitudeRange struct {
+	min, max int
+}
+
+// pick returns a random value in [min, max].
+func (r magnitudeRange) pick() uint64 {
+	return uint64(r.min + rngIntn(r.max-r.min+1))
+}
+
+type callMagnitudes struct {
+	recursion    magnitudeRange // recursiveCall depth
+	delegatecall magnitudeRange // delegatecall recursion depth
+	extRecursion magnitudeRange // external this.call() recursion depth
+	gasGuzzler   magnitudeRange // burnGas iterations
+	logBlaster   magnitudeRange // blastLogs event count
+	memoryBomb   magnitudeRange // expandMemory words
+	storageSpam  magnitudeRange // spamSlots slot count
+}
+
+var magnitudes callMagnitudes
+
+func initMagnitudes() {
+	magnitudes = callMagnitudes{
+		recursion:    envRange("STRESS_RECURSION", magnitudeRange{1, 100}),
+		delegatecall: envRange("STRESS_DELEGATECALL_DEPTH", magnitudeRange{1, 50}),
+		extRecursion: envRange("STRESS_EXT_RECURSION", magnitudeRange{1, 30}),
+		gasGuzzler:   envRange("STRESS_GAS_GUZZLER_ITERS", magnitudeRange{500, 9999}),
+		logBlaster:   envRange("STRESS_LOG_BLASTER_COUNT", magnitudeRange{50, 499}),
+		memoryBomb:   envRange("STRESS_MEMORY_BOMB_WORDS", magnitudeRange{100, 4999}),
+		storageSpam:  envRange("STRESS_STORAGE_SPAM_SLOTS", magnitudeRange{10, 199}),
+	}
+	log.Printf("[contracts] magnitudes: %+v", magnitudes)
+}
+
+// envRange reads <prefix>_MIN and <prefix>_MAX, falling back to def when the
+// resulting range is empty or non-positive.
+func envRange(prefix string, def magnitudeRange) magnitudeRange {
+	r := magnitudeRange{
+		min: envInt(prefix+"_MIN", def.min),
+		max: envInt(prefix+"_MAX", def.max),
+	}
+	if r.min < 1 || r.max < r.min {
+		log.Printf("[config] invalid range %s=[%d,%d], using default [%d,%d]",
+			prefix, r.min, r.max, def.min, def.max)
+		return def
+	}
+	return r
+}
+
 // ===========================================================================
 // EVM Helpers
 // ===========================================================================
@@ -85,6 +219,15 @@ func calcSelector(funcSig string) []byte {
 	return hasher.Sum(nil)[:4]
 }
 
+// keccak256 returns the Keccak-256 hash of the concatenated inputs.
+func keccak256(data ...[]byte) []byte {
+	hasher := sha3.NewLegacyKeccak256()
+	for _, d := range data {
+		hasher.Write(d)
+	}
+	return hasher.Sum(nil)
+}
+
 // encodeUint256 ABI-encodes a uint64 as a 32-byte big-endian uint256.
 func encodeUint256(n uint64) []byte {
 	buf := make([]byte, 32)
@@ -102,6 +245,17 @@ func encodeAddress(addr []byte) []byte {
 	return buf
 }
 
+// encodeString ABI-encodes a single dynamic string argument: head offset,
+// length, then the bytes right-padded to a 32-byte boundary.
+func encodeString(str string) []byte {
+	padded := (len(str) + 31) / 32 * 32
+	buf := make([]byte, 64+padded)
+	copy(buf, encodeUint256(32))
+	copy(buf[32:], encodeUint256(uint64(len(str))))
+	copy(buf[64:], str)
+	return buf
+}
+
 // cborWrapCalldata wraps EVM calldata (selector + args) as CBOR byte array
 // for the MethodsEVM.InvokeContract params field.
 func cborWrapCalldata(selector []byte, args ...[]byte) ([]byte, error) {
@@ -121,20 +275,66 @@ func cborWrapCalldata(selector []byte, args ...[]byte) ([]byte, error) {
 // Contract Message Helpers
 // ===========================================================================
 
+// gasProfile bounds the gas limit of calls to one contract type. The node's
+// estimate wins when available; fallback is used when estimation fails and
+// max caps estimates so a single call never claims the whole block.
+type gasProfile struct {
+	fallback int64
+	max      int64
+}
+
+var defaultGasProfile = gasProfile{fallback: 500_000_000, max: 10_000_000_000}
+
+// contractGasProfiles sizes the fallback to each contract's magnitude range so
+// resource-stress calls reach node limits instead of reverting out-of-gas.
+var contractGasProfiles = map[string]gasProfile{
+	"recursive":          {fallback: 1_000_000_000, max: 5_000_000_000},
+	"delegatecall":       {fallback: 1_000_000_000, max: 5_000_000_000},
+	"extrecursive":       {fallback: 2_000_000_000, max: 5_000_000_000},
+	"simplecoin":         {fallback: 100_000_000, max: 1_000_000_000},
+	"selfdestruct":       {fallback: 100_000_000, max: 1_000_000_000},
+	"gasguzzler":         {fallback: 5_000_000_000, max: 10_000_000_000},
+	"logblaster":         {fallback: 2_000_000_000, max: 8_000_000_000},
+	"memorybomb":         {fallback: 3_000_000_000, max: 10_000_000_000},
+	"storagespam":        {fallback: 4_000_000_000, max: 10_000_000_000},
+	"create2factory":     {fallback: 500_000_000, max: 2_000_000_000},
+	"createfactory":      {fallback: 500_000_000, max: 2_000_000_000},
+	"recreatefactory":    {fallback: 500_000_000, max: 2_000_000_000},
+	"reentrancybank":     {fallback: 200_000_000, max: 2_000_000_000},
+	"reentrancyattacker": {fallback: 500_000_000, max: 4_000_000_000},
+}
+
+// gasProfileFor returns the profile of the deployed contract at addr, or the
+// default for deploys and unknown targets.
+func gasProfileFor(addr address.Address) gasProfile {
+	contractsMu.Lock()
+	defer contractsMu.Unlock()
+	for _, c := range deployedContracts {
+		if c.addr == addr {
+			if p, ok := contractGasProfiles[c.ctype]; ok {
+				return p
+			}
+			break
+		}
+	}
+	return defaultGasProfile
+}
+
 // pushContractMsg estimates gas, signs locally, and pushes a contract message.
 // Returns the message CID and success status.
 func pushContractMsg(node api.FullNode, msg *types.Message, ki *types.KeyInfo, tag string) (cid.Cid, bool) {
 	msg.Nonce = nonces[msg.From]
+	profile := gasProfileFor(msg.To)
 
 	// Let the node estimate gas
 	gasMsg, err := node.GasEstimateMessageGas(ctx, msg, nil, types.EmptyTSK)
 	if err != nil {
-		log.Printf("[%s] GasEstimateMessageGas failed: %v, using fallback", tag, err)
-		msg.GasLimit = 500_000_000
+		log.Printf("[%s] GasEstimateMessageGas failed: %v, using fallback %d", tag, err, profile.fallback)
+		msg.GasLimit = profile.fallback
 		msg.GasFeeCap = abi.NewTokenAmount(150_000)
 		msg.GasPremium = abi.NewTokenAmount(1_000)
 	} else {
-		msg.GasLimit = gasMsg.GasLimit
+		msg.GasLimit = min(gasMsg.GasLimit, profile.max)
 		msg.GasFeeCap = gasMsg.GasFeeCap
 		msg.GasPremium = gasMsg.GasPremium
 	}
@@ -144,13 +344,14 @@ func pushContractMsg(node api.FullNode, msg *types.Message, ki *types.KeyInfo, t
 		return cid.Undef, false
 	}
 
-	msgCid, err := node.MpoolPush(ctx, smsg)
+	msgCid, err := mpoolPush(node, smsg)
 	if err != nil {
 		log.Printf("[%s] MpoolPush failed: %v", tag, err)
 		return cid.Undef, false
 	}
 
 	nonces[msg.From]++
+	trackMsgGas(msgCid)
 	return msgCid, true
 }
 
@@ -176,6 +377,76 @@ func deployContract(node api.FullNode, from address.Address, ki *types.KeyInfo,
 	return pushContractMsg(node, msg, ki, tag)
 }
 
+// deployContractCreate deploys an EVM contract via EAM.Create, whose address
+// is derived from the caller's eth address and createNonce (CREATE rules)
+// rather than from the message itself. The EAM only accepts Create from EVM
+// actors, so the call is relayed through a createfactory contract and the
+// child's address derives from the factory, not the sending wallet.
+func deployContractCreate(node api.FullNode, from address.Address, ki *types.KeyInfo,
+	factory address.Address, bytecode []byte, createNonce uint64, tag string) (cid.Cid, bool) {
+
+	params, aerr := actors.SerializeParams(&eam.CreateParams{Initcode: bytecode, Nonce: createNonce})
+	if aerr != nil {
+		log.Printf("[%s] SerializeParams failed: %v", tag, aerr)
+		return cid.Undef, false
+	}
+	input := encodeCallActorInput(uint64(builtintypes.MethodsEAM.Create), params, builtintypes.EthereumAddressManagerActorAddr)
+	calldata, err := cborWrapCalldata(calcSelector("callActor(bytes)"), input)
+	if err != nil {
+		log.Printf("[%s] cborWrap failed: %v", tag, err)
+		return cid.Undef, false
+	}
+
+	return invokeContract(node, from, ki, factory, calldata, tag)
+}
+
+// callActorCBOR is the multicodec the call_actor precompile expects for
+// CBOR-encoded params and returns.
+const callActorCBOR = 0x51
+
+// encodeCallActorInput ABI-encodes the call_actor precompile input:
+// (uint64 method, uint256 value, uint64 flags, uint64 codec, bytes params,
+// bytes address), with zero value and no flags.
+func encodeCallActorInput(method uint64, params []byte, to address.Address) []byte {
+	paramsPadded := (len(params) + 31) / 32 * 32
+	addrBytes := to.Bytes()
+
+	var buf []byte
+	buf = append(buf, encodeUint256(method)...)
+	buf = append(buf, encodeUint256(0)...)
+	buf = append(buf, encodeUint256(0)...)
+	buf = append(buf, encodeUint256(callActorCBOR)...)
+	buf = append(buf, encodeUint256(6*32)...)
+	buf = append(buf, encodeUint256(uint64(6*32+32+paramsPadded))...)
+	buf = append(buf, encodeUint256(uint64(len(params)))...)
+	buf = append(buf, params...)
+	buf = append(buf, make([]byte, paramsPadded-len(params))...)
+	buf = append(buf, encodeUint256(uint64(len(addrBytes)))...)
+	buf = append(buf, addrBytes...)
+	buf = append(buf, make([]byte, 32-len(addrBytes))...)
+	return buf
+}
+
+// decodeCallActorReturn extracts the called actor's return bytes from the
+// ABI-encoded (int256 exit, uint64 codec, bytes data) precompile output.
+func decodeCallActorReturn(out []byte) ([]byte, error) {
+	if len(out) < 96 {
+		return nil, fmt.Errorf("call_actor output too short: %d bytes", len(out))
+	}
+	if exit := new(big.Int).SetBytes(out[:32]); exit.Sign() != 0 {
+		return nil, fmt.Errorf("called actor exited %s", exit)
+	}
+	off := binary.BigEndian.Uint64(out[88:96])
+	if off+32 > uint64(len(out)) {
+		return nil, fmt.Errorf("call_actor data offset %d out of range", off)
+	}
+	n := binary.BigEndian.Uint64(out[off+24 : off+32])
+	if off+32+n > uint64(len(out)) {
+		return nil, fmt.Errorf("call_actor data length %d out of range", n)
+	}
+	return out[off+32 : off+32+n], nil
+}
+
 // invokeContract invokes a deployed EVM contract with the given calldata.
 func invokeContract(node api.FullNode, from address.Address, ki *types.KeyInfo,
 	contractAddr address.Address, calldata []byte, tag string) (cid.Cid, bool) {
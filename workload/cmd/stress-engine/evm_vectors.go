@@ -3,18 +3,28 @@ package main
 import (
 	"bytes"
 	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"log"
+	"math/big"
+	"os"
+	"strings"
 	"sync"
 	"time"
 
-	"github.com/antithesishq/antithesis-sdk-go/assert"
 	"github.com/antithesishq/antithesis-sdk-go/random"
 
 	"github.com/filecoin-project/go-address"
 	"github.com/filecoin-project/go-state-types/abi"
 	builtintypes "github.com/filecoin-project/go-state-types/builtin"
 	"github.com/filecoin-project/go-state-types/builtin/v15/eam"
+	"github.com/filecoin-project/go-state-types/exitcode"
+	"github.com/filecoin-project/lotus/api"
 	"github.com/filecoin-project/lotus/chain/types"
+	"github.com/filecoin-project/lotus/chain/types/ethtypes"
+	"github.com/ipfs/go-cid"
 )
 
 const stateWaitTimeout = 2 * time.Minute
@@ -22,13 +32,21 @@ const stateWaitTimeout = 2 * time.Minute
 // ===========================================================================
 // Vector 7: DoDeployContracts (FVM Stress — Contract Deployment)
 //
-// Deploys EVM contracts via EAM.CreateExternal to stress the Init actor,
-// state tree growth, and FVM constructor execution. On subsequent calls,
-// checks pending deploys for confirmation and registers deployed contracts.
+// Deploys EVM contracts via EAM.CreateExternal or, at random, EAM.Create
+// relayed through a createfactory contract (address derived from the
+// factory + nonce) to stress the Init actor, address derivation, state tree
+// growth, and FVM constructor execution. On subsequent calls, checks pending
+// deploys for confirmation and registers deployed contracts. Each confirmed
+// Create is re-submitted with the same nonce, which must collide and fail
+// identically on every node.
 // ===========================================================================
 
 const maxPendingDeploys = 50
 
+// createFactoryNonces is the next EAM.Create nonce per createfactory.
+// Only touched from the main loop.
+var createFactoryNonces = make(map[address.Address]uint64)
+
 func DoDeployContracts() {
 	// Phase 1: Check pending deploys for confirmation
 	resolvePendingDeploys()
@@ -43,9 +61,34 @@ func DoDeployContracts() {
 	fromAddr, fromKI := pickWallet()
 	nodeName, node := pickNode()
 
-	msgCid, ok := deployContract(node, fromAddr, fromKI, bytecode, "deploy-"+ctype)
+	// EAM.Create only accepts EVM callers, so the Create path needs a
+	// confirmed createfactory; until one exists, deploy it and fall back
+	// to CreateExternal.
+	var factory address.Address
+	viaCreate := rngIntn(2) == 0
+	if viaCreate {
+		if factories := getContractsByType("createfactory"); len(factories) > 0 {
+			factory = rngChoice(factories).addr
+		} else {
+			doDeployStressContract("createfactory")
+			viaCreate = false
+		}
+	}
+	createNonce := createFactoryNonces[factory]
+	var (
+		msgCid cid.Cid
+		ok     bool
+	)
+	if viaCreate {
+		msgCid, ok = deployContractCreate(node, fromAddr, fromKI, factory, bytecode, createNonce, "deploy-create-"+ctype)
+		if ok {
+			createFactoryNonces[factory]++
+		}
+	} else {
+		msgCid, ok = deployContract(node, fromAddr, fromKI, bytecode, "deploy-"+ctype)
+	}
 	if !ok {
-		log.Printf("[deploy] failed to deploy %s via %s", ctype, nodeName)
+		log.Printf("[deploy] failed to deploy %s via %s (create=%v)", ctype, nodeName, viaCreate)
 		return
 	}
 
@@ -59,16 +102,19 @@ func DoDeployContracts() {
 	pendingMu.Lock()
 	if len(pendingDeploys) < maxPendingDeploys {
 		pendingDeploys = append(pendingDeploys, pendingDeploy{
-			msgCid:   msgCid,
-			ctype:    ctype,
-			deployer: fromAddr,
-			deployKI: fromKI,
-			epoch:    epoch,
+			msgCid:      msgCid,
+			ctype:       ctype,
+			deployer:    fromAddr,
+			deployKI:    fromKI,
+			epoch:       epoch,
+			viaCreate:   viaCreate,
+			factory:     factory,
+			createNonce: createNonce,
 		})
 	}
 	pendingMu.Unlock()
 
-	debugLog("  [deploy] submitted %s deploy via %s (cid=%s)", ctype, nodeName, msgCid.String()[:16])
+	debugLog("  [deploy] submitted %s deploy via %s (create=%v cid=%s)", ctype, nodeName, viaCreate, msgCid.String()[:16])
 }
 
 func resolvePendingDeploys() {
@@ -92,11 +138,15 @@ func resolvePendingDeploys() {
 			continue
 		}
 
+		if pd.redeploy {
+			verifyCreateRedeploy(pd, result.Receipt.ExitCode)
+			continue
+		}
+
 		if result.Receipt.ExitCode.IsSuccess() {
-			// Decode the CreateExternalReturn to get the contract address
-			var ret eam.CreateExternalReturn
-			if err := ret.UnmarshalCBOR(bytes.NewReader(result.Receipt.Return)); err != nil {
-				log.Printf("[deploy] failed to decode CreateReturn: %v", err)
+			ret, err := decodeDeployReturn(pd, result.Receipt.Return)
+			if err != nil {
+				log.Printf("[deploy] failed to decode create return: %v", err)
 				continue
 			}
 			idAddr, err := address.NewIDAddress(ret.ActorID)
@@ -105,16 +155,26 @@ func resolvePendingDeploys() {
 				continue
 			}
 
+			dc := deployedContract{
+				addr:      idAddr,
+				ctype:     pd.ctype,
+				deployer:  pd.deployer,
+				deployKI:  pd.deployKI,
+				viaCreate: pd.viaCreate,
+			}
 			contractsMu.Lock()
-			deployedContracts = append(deployedContracts, deployedContract{
-				addr:     idAddr,
-				ctype:    pd.ctype,
-				deployer: pd.deployer,
-				deployKI: pd.deployKI,
-			})
+			deployedContracts = append(deployedContracts, dc)
+			contractDeployCounts[pd.ctype]++
 			contractsMu.Unlock()
 
 			debugLog("  [deploy] confirmed %s at %s (actor=%d)", pd.ctype, idAddr, ret.ActorID)
+
+			if deployWarmCall {
+				warmCall(dc)
+			}
+			if pd.viaCreate {
+				submitCreateRedeploy(pd)
+			}
 		} else {
 			log.Printf("  [deploy] %s failed with exit code %d", pd.ctype, result.Receipt.ExitCode)
 		}
@@ -127,6 +187,122 @@ func resolvePendingDeploys() {
 	}
 }
 
+// decodeDeployReturn decodes the EAM return matching the creation path. A
+// factory-relayed Create returns the EAM result wrapped in the factory's
+// EVM return data.
+func decodeDeployReturn(pd pendingDeploy, raw []byte) (eam.Return, error) {
+	if pd.viaCreate {
+		var evmRet abi.CborBytes
+		if err := evmRet.UnmarshalCBOR(bytes.NewReader(raw)); err != nil {
+			return eam.Return{}, err
+		}
+		data, err := decodeCallActorReturn(evmRet)
+		if err != nil {
+			return eam.Return{}, err
+		}
+		var ret eam.CreateReturn
+		err = ret.UnmarshalCBOR(bytes.NewReader(data))
+		return eam.Return(ret), err
+	}
+	var ret eam.CreateExternalReturn
+	err := ret.UnmarshalCBOR(bytes.NewReader(raw))
+	return eam.Return(ret), err
+}
+
+// submitCreateRedeploy re-sends a confirmed EAM.Create through the same
+// factory with the same nonce. The derived address is already taken, so the
+// EAM call must fail and the factory must revert.
+func submitCreateRedeploy(pd pendingDeploy) {
+	nodeName, node := pickNode()
+	msgCid, ok := deployContractCreate(node, pd.deployer, pd.deployKI, pd.factory, contractBytecodes[pd.ctype], pd.createNonce, "redeploy-create-"+pd.ctype)
+	if !ok {
+		return
+	}
+
+	pd.msgCid = msgCid
+	pd.redeploy = true
+	pendingMu.Lock()
+	pendingDeploys = append(pendingDeploys, pd)
+	pendingMu.Unlock()
+
+	debugLog("  [deploy] re-submitted %s create nonce=%d via %s (cid=%s)", pd.ctype, pd.createNonce, nodeName, cidStr(msgCid))
+}
+
+// verifyCreateRedeploy asserts a same-nonce Create failed, with the same exit
+// code on every node that has executed it.
+func verifyCreateRedeploy(pd pendingDeploy, code exitcode.ExitCode) {
+	codes := make(map[string][]string) // exit code -> []nodeName
+	for _, name := range nodeKeys {
+		result, err := nodes[name].StateSearchMsg(ctx, types.EmptyTSK, pd.msgCid, 100, true)
+		if err != nil || result == nil {
+			continue
+		}
+		key := result.Receipt.ExitCode.String()
+		codes[key] = append(codes[key], name)
+	}
+
+	deterministic := !code.IsSuccess() && len(codes) == 1
+	alwaysAssert(deterministic, aidDeployCreateRedeployFails, withDivergence(codes, map[string]any{
+		"ctype":        pd.ctype,
+		"deployer":     pd.deployer.String(),
+		"factory":      pd.factory.String(),
+		"create_nonce": pd.createNonce,
+		"msg_cid":      pd.msgCid.String(),
+		"exit_codes":   codes,
+	}))
+
+	if !deterministic {
+		log.Printf("[deploy] CREATE REDEPLOY not a deterministic failure: %s nonce=%d codes=%v",
+			pd.ctype, pd.createNonce, codes)
+		return
+	}
+	debugLog("  [deploy] OK: same-nonce create of %s failed with %s everywhere", pd.ctype, code)
+}
+
+// deployWarmCall enables a small state-populating call right after each
+// deploy confirms, so read-back checks have known state immediately.
+var deployWarmCall = os.Getenv("STRESS_DEPLOY_WARM_CALL") == "1"
+
+// warmCall issues a contract-type-specific call that seeds a freshly
+// deployed contract with state. Types without useful state are skipped.
+func warmCall(c deployedContract) {
+	var (
+		calldata []byte
+		err      error
+	)
+	switch c.ctype {
+	case "storagespam":
+		// spamSlots(count, seed) — a handful of slots with a fixed seed
+		calldata, err = cborWrapCalldata(
+			calcSelector("spamSlots(uint256,uint256)"),
+			encodeUint256(5),
+			encodeUint256(1),
+		)
+	case "simplecoin":
+		// The constructor mints to the deployer; move a little to a second
+		// holder so balances exist on both sides of a transfer.
+		toAddr, _ := pickWallet()
+		calldata, err = cborWrapCalldata(
+			calcSelector("sendCoin(address,uint256)"),
+			encodeAddress(toAddr.Payload()),
+			encodeUint256(1),
+		)
+	default:
+		return
+	}
+	if err != nil {
+		return
+	}
+
+	nodeName, node := pickNode()
+	msgCid, ok := invokeContract(node, c.deployer, c.deployKI, c.addr, calldata, "warm-"+c.ctype)
+	if ok {
+		recordContractCall(c.ctype)
+	}
+
+	debugLog("  [deploy] warm call %s at %s via %s ok=%v cid=%s", c.ctype, c.addr, nodeName, ok, cidStr(msgCid))
+}
+
 // ===========================================================================
 // Vector 8: DoContractCall (FVM Stress — Contract Invocation)
 //
@@ -135,9 +311,47 @@ func resolvePendingDeploys() {
 // - Delegatecall recursion (RecursiveDelegatecall.recursiveCall)
 // - SimpleCoin token transfers
 // - External recursive calls (StackRecCallExp.exec1)
+//
+// Sub-actions are picked from a weighted deck (STRESS_CALL_WEIGHT_*),
+// equal by default, so operators can shift FVM stress composition.
 // ===========================================================================
 
+// contractCallDeck is the weighted sub-action deck for DoContractCall.
+var contractCallDeck []namedAction
+
+// buildContractCallDeck builds contractCallDeck from env weights, mirroring buildDeck.
+func buildContractCallDeck() {
+	subActions := []struct {
+		name      string
+		envVar    string
+		fn        func()
+		defWeight int
+	}{
+		{"deep-recursion", "STRESS_CALL_WEIGHT_RECURSION", doDeepRecursion, 1},
+		{"delegatecall-recursion", "STRESS_CALL_WEIGHT_DELEGATECALL", doDelegatecallRecursion, 1},
+		{"simplecoin-transfer", "STRESS_CALL_WEIGHT_SIMPLECOIN", doSimpleCoinTransfer, 1},
+		{"external-recursion", "STRESS_CALL_WEIGHT_EXT_RECURSION", doExternalRecursion, 1},
+	}
+
+	contractCallDeck = nil
+	for _, a := range subActions {
+		w := envInt(a.envVar, a.defWeight)
+		if w > 0 {
+			log.Printf("[init] contract-call sub-action %s: weight=%d", a.name, w)
+		}
+		for i := 0; i < w; i++ {
+			contractCallDeck = append(contractCallDeck, namedAction{name: a.name, fn: a.fn})
+		}
+	}
+
+	if len(contractCallDeck) == 0 {
+		log.Printf("[init] WARN: contract-call deck is empty, DoContractCall will no-op")
+	}
+}
+
 func DoContractCall() {
+	resolvePendingCalls()
+
 	contractsMu.Lock()
 	numContracts := len(deployedContracts)
 	contractsMu.Unlock()
@@ -146,21 +360,13 @@ func DoContractCall() {
 		log.Printf("  [contract-call] SKIP: no deployed contracts yet")
 		return
 	}
-
-	subAction := rngIntn(4)
-	subNames := []string{"deep-recursion", "delegatecall-recursion", "simplecoin-transfer", "external-recursion"}
-	debugLog("  [contract-call] sub-action: %s", subNames[subAction])
-
-	switch subAction {
-	case 0:
-		doDeepRecursion()
-	case 1:
-		doDelegatecallRecursion()
-	case 2:
-		doSimpleCoinTransfer()
-	case 3:
-		doExternalRecursion()
+	if len(contractCallDeck) == 0 {
+		return
 	}
+
+	sub := rngChoice(contractCallDeck)
+	debugLog("  [contract-call] sub-action: %s", sub.name)
+	sub.fn()
 }
 
 func doDeepRecursion() {
@@ -171,8 +377,7 @@ func doDeepRecursion() {
 	c := rngChoice(contracts)
 	nodeName, node := pickNode()
 
-	// Random recursion depth: 1-100
-	depth := uint64(rngIntn(100) + 1)
+	depth := magnitudes.recursion.pick()
 
 	// recursiveCall(uint256)
 	calldata, err := cborWrapCalldata(calcSelector("recursiveCall(uint256)"), encodeUint256(depth))
@@ -182,6 +387,10 @@ func doDeepRecursion() {
 	}
 
 	msgCid, ok := invokeContract(node, c.deployer, c.deployKI, c.addr, calldata, "recursive-call")
+	if ok {
+		recordContractCall(c.ctype)
+		trackCall(node, msgCid, "recursive-call", depth, expectForDepth(depth, recursionSafeDepth))
+	}
 
 	debugLog("  [contract-call] recursive depth=%d via %s ok=%v cid=%s",
 		depth, nodeName, ok, cidStr(msgCid))
@@ -195,8 +404,8 @@ func doDelegatecallRecursion() {
 	c := rngChoice(contracts)
 	nodeName, node := pickNode()
 
-	// Random recursion depth: 1-50 (delegatecall is more expensive)
-	depth := uint64(rngIntn(50) + 1)
+	// Shallower default range: delegatecall is more expensive
+	depth := magnitudes.delegatecall.pick()
 
 	// recursiveCall(uint256)
 	calldata, err := cborWrapCalldata(calcSelector("recursiveCall(uint256)"), encodeUint256(depth))
@@ -205,6 +414,10 @@ func doDelegatecallRecursion() {
 	}
 
 	msgCid, ok := invokeContract(node, c.deployer, c.deployKI, c.addr, calldata, "delegatecall-call")
+	if ok {
+		recordContractCall(c.ctype)
+		trackCall(node, msgCid, "delegatecall-call", depth, expectForDepth(depth, delegatecallSafeDepth))
+	}
 
 	debugLog("  [contract-call] delegatecall depth=%d via %s ok=%v cid=%s",
 		depth, nodeName, ok, cidStr(msgCid))
@@ -236,6 +449,10 @@ func doSimpleCoinTransfer() {
 	}
 
 	msgCid, ok := invokeContract(node, c.deployer, c.deployKI, c.addr, calldata, "simplecoin-send")
+	if ok {
+		recordContractCall(c.ctype)
+		trackCall(node, msgCid, "simplecoin-send", 0, expectSuccess) // insufficient balance returns false, never reverts
+	}
 
 	debugLog("  [contract-call] simplecoin send amount=%d via %s ok=%v cid=%s",
 		amount, nodeName, ok, cidStr(msgCid))
@@ -249,8 +466,8 @@ func doExternalRecursion() {
 	c := rngChoice(contracts)
 	nodeName, node := pickNode()
 
-	// Random recursion depth: 1-30 (external calls are very expensive)
-	depth := uint64(rngIntn(30) + 1)
+	// Shallowest default range: external calls are very expensive
+	depth := magnitudes.extRecursion.pick()
 
 	// exec1(uint256)
 	calldata, err := cborWrapCalldata(calcSelector("exec1(uint256)"), encodeUint256(depth))
@@ -259,18 +476,173 @@ func doExternalRecursion() {
 	}
 
 	msgCid, ok := invokeContract(node, c.deployer, c.deployKI, c.addr, calldata, "ext-recursive-call")
+	if ok {
+		recordContractCall(c.ctype)
+		trackCall(node, msgCid, "ext-recursive-call", depth, expectForDepth(depth, extRecursionSafeDepth))
+	}
 
 	debugLog("  [contract-call] external recursion depth=%d via %s ok=%v cid=%s",
 		depth, nodeName, ok, cidStr(msgCid))
 }
 
+// ---------------------------------------------------------------------------
+// Deferred call verification
+//
+// Sub-actions above record each submitted call; the next DoContractCall
+// looks up its receipt and checks the exit code against the expected
+// outcome for the requested depth. Calls past the FVM call-stack limit must
+// fail; calls within the default magnitude ranges must succeed; anything in
+// between may go either way but must do so identically on every node.
+// ---------------------------------------------------------------------------
+
+type callOutcome int
+
+const (
+	expectAny callOutcome = iota
+	expectSuccess
+	expectFailure
+)
+
+func (o callOutcome) String() string {
+	return [...]string{"any", "success", "failure"}[o]
+}
+
+const (
+	maxPendingCalls     = 50
+	pendingCallLookback = 100  // StateSearchMsg lookback; older unresolved calls are dropped
+	callStackLimit      = 1024 // FVM/EVM maximum call depth
+
+	// Depths at or below these always fit in gas and stack (the default
+	// magnitude maxima); operators can push ranges past them.
+	recursionSafeDepth    = 100
+	delegatecallSafeDepth = 50
+	extRecursionSafeDepth = 30
+)
+
+// expectForDepth classifies a recursion depth into its expected outcome.
+func expectForDepth(depth, safe uint64) callOutcome {
+	switch {
+	case depth <= safe:
+		return expectSuccess
+	case depth > callStackLimit:
+		return expectFailure
+	default:
+		return expectAny
+	}
+}
+
+// trackCall queues a submitted call for verification by resolvePendingCalls,
+// stamped with node's current head as the submit epoch.
+func trackCall(node api.FullNode, msgCid cid.Cid, tag string, depth uint64, expect callOutcome) {
+	epoch := abi.ChainEpoch(0)
+	if head, err := node.ChainHead(ctx); err == nil {
+		epoch = head.Height()
+	}
+
+	pendingMu.Lock()
+	defer pendingMu.Unlock()
+	if len(pendingCalls) < maxPendingCalls {
+		pendingCalls = append(pendingCalls, pendingCall{msgCid: msgCid, tag: tag, depth: depth, expect: expect, epoch: epoch})
+	}
+}
+
+func resolvePendingCalls() {
+	pendingMu.Lock()
+	pending := pendingCalls
+	pendingCalls = nil
+	pendingMu.Unlock()
+
+	if len(pending) == 0 {
+		return
+	}
+
+	primary := nodeKeys[0]
+	var headHeight abi.ChainEpoch
+	if head, err := nodes[primary].ChainHead(ctx); err == nil {
+		headHeight = head.Height()
+	}
+
+	var remaining []pendingCall
+	for _, pc := range pending {
+		result, err := nodes[primary].StateSearchMsg(ctx, types.EmptyTSK, pc.msgCid, pendingCallLookback, true)
+		if err != nil || result == nil {
+			// Past the lookback the search can no longer find it
+			if headHeight-pc.epoch > pendingCallLookback {
+				log.Printf("[contract-call] dropping %s depth=%d cid=%s: unresolved %d epochs after submit",
+					pc.tag, pc.depth, cidStr(pc.msgCid), headHeight-pc.epoch)
+				continue
+			}
+			// Not found yet — keep waiting
+			remaining = append(remaining, pc)
+			continue
+		}
+
+		code := result.Receipt.ExitCode
+		details := map[string]any{
+			"node":      primary,
+			"node_type": nodeType(primary),
+			"call":      pc.tag,
+			"depth":     pc.depth,
+			"expect":    pc.expect.String(),
+			"exit_code": code.String(),
+			"msg_cid":   pc.msgCid.String(),
+		}
+
+		expected := true
+		switch pc.expect {
+		case expectSuccess:
+			expected = code.IsSuccess()
+			alwaysAssert(expected, aidContractCallSucceeds, details)
+		case expectFailure:
+			expected = !code.IsSuccess()
+			alwaysAssert(expected, aidContractCallOverflowFails, details)
+		}
+		if !expected {
+			log.Printf("[contract-call] UNEXPECTED OUTCOME %s depth=%d expect=%s exit=%s cid=%s",
+				pc.tag, pc.depth, pc.expect, code, cidStr(pc.msgCid))
+		}
+
+		// Reverts must be deterministic: compare with a second node.
+		if !code.IsSuccess() && len(nodeKeys) > 1 {
+			other := nodeKeys[1+rngIntn(len(nodeKeys)-1)]
+			otherResult, err := nodes[other].StateSearchMsg(ctx, types.EmptyTSK, pc.msgCid, pendingCallLookback, true)
+			if err == nil && otherResult != nil {
+				details["other_node"] = other
+				details["other_exit_code"] = otherResult.Receipt.ExitCode.String()
+				same := otherResult.Receipt.ExitCode == code
+				alwaysAssert(same, aidContractCallRevertDeterministic, details)
+				if !same {
+					log.Printf("[contract-call] REVERT DIVERGENCE %s cid=%s: %s=%s %s=%s",
+						pc.tag, cidStr(pc.msgCid), primary, code, other, otherResult.Receipt.ExitCode)
+				}
+			}
+		}
+
+		debugLog("  [contract-call] resolved %s depth=%d expect=%s exit=%s", pc.tag, pc.depth, pc.expect, code)
+	}
+
+	if len(remaining) > 0 {
+		pendingMu.Lock()
+		pendingCalls = append(remaining, pendingCalls...)
+		pendingMu.Unlock()
+	}
+}
+
 // ===========================================================================
 // Vector 9: DoSelfDestructCycle (Actor Lifecycle Stress)
 //
-// Deploys a SelfDestruct contract, then calls destroy() to kill it.
-// Verifies actor state is consistent across nodes after destruction.
+// Deploys a SelfDestruct contract, funds it with a bare send (its
+// constructor is non-payable), then calls destroy() to kill it. Verifies
+// actor state is consistent across nodes after destruction, and that the
+// contract's balance was swept to the caller: the beneficiary's balance
+// change plus the destroy message's gas cost must equal what the contract
+// held, on every node.
 // ===========================================================================
 
+// selfDestructFundUnit is the attoFIL granularity of the balance a
+// SelfDestruct contract holds before destroy().
+const selfDestructFundUnit = 1_000_000_000_000 // 1e12 attoFIL
+
 func DoSelfDestructCycle() {
 	fromAddr, fromKI := pickWallet()
 	_, node := pickNode()
@@ -309,8 +681,37 @@ func DoSelfDestructCycle() {
 	if err != nil {
 		return
 	}
+	recordContractDeploy("selfdestruct")
+
+	// Fund the contract so destroy() has something to sweep.
+	fundMsg := &types.Message{
+		From:   fromAddr,
+		To:     contractAddr,
+		Value:  abi.NewTokenAmount(int64(1+rngIntn(1000)) * selfDestructFundUnit),
+		Method: builtintypes.MethodSend,
+	}
+	fundCid, ok := pushContractMsg(node, fundMsg, fromKI, "selfdestruct-fund")
+	if !ok {
+		return
+	}
+	waitCtxF, waitCancelF := context.WithTimeout(ctx, stateWaitTimeout)
+	fundResult, err := node.StateWaitMsg(waitCtxF, fundCid, 1, 200, false)
+	waitCancelF()
+	if err != nil {
+		log.Printf("[selfdestruct] fund StateWaitMsg failed: %v", err)
+		return
+	}
+	if !fundResult.Receipt.ExitCode.IsSuccess() {
+		log.Printf("[selfdestruct] fund failed with exit code %d", fundResult.Receipt.ExitCode)
+		return
+	}
+	funded, err := node.StateGetActor(ctx, contractAddr, fundResult.TipSet)
+	if err != nil {
+		log.Printf("[selfdestruct] StateGetActor after funding failed: %v", err)
+		return
+	}
 
-	debugLog("  [selfdestruct] deployed at %s, now destroying...", contractAddr)
+	debugLog("  [selfdestruct] deployed at %s holding %s, now destroying...", contractAddr, funded.Balance)
 
 	// Call destroy() on the contract
 	calldata, err := cborWrapCalldata(calcSelector("destroy()"))
@@ -322,6 +723,7 @@ func DoSelfDestructCycle() {
 	if !ok {
 		return
 	}
+	recordContractCall("selfdestruct")
 
 	// Wait for destroy confirmation (with timeout to avoid blocking the main loop)
 	waitCtx2, waitCancel2 := context.WithTimeout(ctx, stateWaitTimeout)
@@ -373,7 +775,7 @@ func DoSelfDestructCycle() {
 			}
 		}
 
-		assert.Always(allSame, "Actor state is consistent after self-destruct", map[string]any{
+		alwaysAssert(allSame, aidSelfDestructConsistent, map[string]any{
 			"contract": contractAddr.String(),
 			"results":  results,
 		})
@@ -382,6 +784,88 @@ func DoSelfDestructCycle() {
 			log.Printf("[selfdestruct] STATE DIVERGENCE after destroy: %v", results)
 		}
 	}
+
+	verifySelfDestructSweep(node, contractAddr, fromAddr, funded.Balance, destroyCid, destroyResult)
+}
+
+// verifySelfDestructSweep checks, on every node, that destroy() moved the
+// contract's whole balance to the beneficiary (the caller, who also paid
+// gas): beneficiary delta across the inclusion tipset + gas cost == prior.
+// The exact check needs StateReplay for the gas cost and is skipped if any
+// other message in the inclusion tipset touches the beneficiary; the
+// cross-node comparison of the observed delta always runs.
+func verifySelfDestructSweep(node api.FullNode, contractAddr, beneficiary address.Address,
+	prior types.BigInt, destroyCid cid.Cid, destroyResult *api.MsgLookup) {
+
+	execTs, err := node.ChainGetTipSet(ctx, destroyResult.TipSet)
+	if err != nil {
+		return
+	}
+	inclKey := execTs.Parents()
+	msgs, err := node.ChainGetParentMessages(ctx, execTs.Cids()[0])
+	if err != nil {
+		return
+	}
+	isolated := true
+	for _, m := range msgs {
+		if m.Cid != destroyCid && (m.Message.From == beneficiary || m.Message.To == beneficiary) {
+			isolated = false
+			break
+		}
+	}
+
+	deltas := make(map[string][]string) // beneficiary delta -> []nodeName
+	for _, name := range nodeKeys {
+		before, err := nodes[name].StateGetActor(ctx, beneficiary, inclKey)
+		if err != nil {
+			continue
+		}
+		after, err := nodes[name].StateGetActor(ctx, beneficiary, destroyResult.TipSet)
+		if err != nil {
+			continue
+		}
+		delta := types.BigSub(after.Balance, before.Balance)
+		deltas[delta.String()] = append(deltas[delta.String()], name)
+
+		if !isolated || !nodeSupports(name, "StateReplay") {
+			continue
+		}
+		replay, err := nodes[name].StateReplay(ctx, types.EmptyTSK, destroyCid)
+		if err != nil || replay == nil {
+			continue
+		}
+		swept := types.BigAdd(delta, replay.GasCost.TotalCost)
+		exact := swept.Equals(prior)
+		alwaysAssert(exact, aidSelfDestructSwept, map[string]any{
+			"node":        name,
+			"node_type":   nodeType(name),
+			"contract":    contractAddr.String(),
+			"beneficiary": beneficiary.String(),
+			"prior":       prior.String(),
+			"delta":       delta.String(),
+			"gas_cost":    replay.GasCost.TotalCost.String(),
+			"destroy_cid": destroyCid.String(),
+		})
+		if !exact {
+			log.Printf("[selfdestruct] SWEEP MISMATCH on %s for %s: prior=%s delta=%s gas=%s",
+				name, contractAddr, prior, delta, replay.GasCost.TotalCost)
+		}
+	}
+	if len(deltas) == 0 {
+		return
+	}
+
+	agree := len(deltas) == 1
+	alwaysAssert(agree, aidSelfDestructSweepAgree, withDivergence(deltas, map[string]any{
+		"contract":    contractAddr.String(),
+		"beneficiary": beneficiary.String(),
+		"prior":       prior.String(),
+		"destroy_cid": destroyCid.String(),
+		"deltas":      deltas,
+	}))
+	if !agree {
+		log.Printf("[selfdestruct] SWEEP DIVERGENCE for %s: %v", contractAddr, deltas)
+	}
 }
 
 // ===========================================================================
@@ -495,20 +979,158 @@ func DoConflictingContractCalls() {
 	wg.Add(2)
 	go func() {
 		defer wg.Done()
-		_, errA = nodes[nodeA].MpoolPush(ctx, smsgA)
+		_, errA = mpoolPush(nodes[nodeA], smsgA)
 	}()
 	go func() {
 		defer wg.Done()
-		_, errB = nodes[nodeB].MpoolPush(ctx, smsgB)
+		_, errB = mpoolPush(nodes[nodeB], smsgB)
 	}()
 	wg.Wait()
 
 	nonces[c.deployer]++
+	if errA == nil || errB == nil {
+		recordContractCall(c.ctype)
+	}
 
 	debugLog("[contract-race] conflicting sendCoin: nodeA=%s err=%v, nodeB=%s err=%v",
 		nodeA, errA, nodeB, errB)
 }
 
+// ===========================================================================
+// DoDeployFanIn (Init Actor — Concurrent Cross-Node Deploys)
+//
+// Deploys the same bytecode from a different wallet via every node in one
+// invocation, so the deploys race through different mempools into the same
+// epochs. Every deploy must get its own actor ID, and once the deploys are
+// final every node must serve identical runtime code for every resulting
+// contract at the finalized tipset. The whole vector waits at most
+// deployFanInMaxWait; if the deploys are not final by then the code checks
+// are skipped, so paused or partitioned nodes cannot fail them.
+// ===========================================================================
+
+const deployFanInMaxWait = 3 * time.Minute // total wait for inclusion and finality
+
+func DoDeployFanIn() {
+	if len(nodeKeys) < 2 || len(contractTypes) == 0 {
+		return
+	}
+	ctype := rngChoice(contractTypes)
+	bytecode := contractBytecodes[ctype]
+
+	type fanInDeploy struct {
+		node   string
+		from   address.Address
+		msgCid cid.Cid
+	}
+	var deploys []fanInDeploy
+	usedWallets := make(map[address.Address]bool)
+	for _, name := range nodeKeys {
+		fromAddr, fromKI := pickWallet()
+		if usedWallets[fromAddr] {
+			continue
+		}
+		usedWallets[fromAddr] = true
+		msgCid, ok := deployContract(nodes[name], fromAddr, fromKI, bytecode, "fanin-deploy-"+ctype)
+		if ok {
+			deploys = append(deploys, fanInDeploy{node: name, from: fromAddr, msgCid: msgCid})
+		}
+	}
+	if len(deploys) < 2 {
+		return
+	}
+
+	waitCtx, waitCancel := context.WithTimeout(ctx, deployFanInMaxWait)
+	defer waitCancel()
+
+	// Resolve each deploy via the node it was submitted to
+	actorIDs := make(map[uint64]string) // actor ID -> msg CID
+	var created []ethtypes.EthAddress
+	var execHeight abi.ChainEpoch
+	for _, d := range deploys {
+		result, err := nodes[d.node].StateWaitMsg(waitCtx, d.msgCid, 1, 200, false)
+		if err != nil || !result.Receipt.ExitCode.IsSuccess() {
+			debugLog("  [deploy-fanin] deploy %s via %s did not succeed: %v", cidStr(d.msgCid), d.node, err)
+			continue
+		}
+		var ret eam.CreateExternalReturn
+		if err := ret.UnmarshalCBOR(bytes.NewReader(result.Receipt.Return)); err != nil {
+			log.Printf("[deploy-fanin] failed to decode CreateReturn: %v", err)
+			continue
+		}
+
+		prev, dup := actorIDs[ret.ActorID]
+		alwaysAssert(!dup, aidFanInDistinctIDs, map[string]any{
+			"actor_id": ret.ActorID,
+			"msg_cid":  d.msgCid.String(),
+			"prev_cid": prev,
+			"ctype":    ctype,
+		})
+		actorIDs[ret.ActorID] = d.msgCid.String()
+		created = append(created, ethtypes.EthAddress(ret.EthAddress))
+		if result.Height > execHeight {
+			execHeight = result.Height
+		}
+	}
+	if len(created) == 0 {
+		return
+	}
+
+	// Wait, within the same budget, until every node has finalized the
+	// tipsets that executed the deploys.
+	finalizedHeight, final := waitFinalized(waitCtx, execHeight)
+	if !final {
+		debugLog("  [deploy-fanin] deploys at %d not final within %s (finalized=%d), skipping code checks",
+			execHeight, deployFanInMaxWait, finalizedHeight)
+		return
+	}
+
+	// Every node must serve the same runtime code for every contract at the
+	// finalized tipset
+	blk := ethtypes.NewEthBlockNumberOrHashFromNumber(ethtypes.EthUint64(finalizedHeight))
+	codes := make(map[string][]string) // code hash -> []node/contract
+	missing := []string{}
+	for _, name := range nodeKeys {
+		for _, ethAddr := range created {
+			who := name + "/" + ethAddr.String()
+			code, err := nodes[name].EthGetCode(ctx, ethAddr, blk)
+			if err != nil {
+				debugLog("  [deploy-fanin] EthGetCode(%s) at %d failed on %s: %v", ethAddr, finalizedHeight, name, err)
+				continue
+			}
+			if len(code) == 0 {
+				missing = append(missing, who)
+				continue
+			}
+			key := ethtypes.EthHashFromTxBytes(code).String()
+			codes[key] = append(codes[key], who)
+		}
+	}
+
+	allSeen := len(missing) == 0
+	alwaysAssert(allSeen, aidFanInAllSeen, map[string]any{
+		"ctype":       ctype,
+		"contracts":   len(created),
+		"exec_height": execHeight,
+		"finalized":   finalizedHeight,
+		"missing":     missing,
+	})
+
+	identical := len(codes) <= 1
+	alwaysAssert(identical, aidFanInIdenticalCode, map[string]any{
+		"ctype":     ctype,
+		"contracts": len(created),
+		"codes":     codes,
+	})
+
+	if !allSeen || !identical {
+		log.Printf("[deploy-fanin] FAN-IN MISMATCH for %s: missing=%v codes=%v", ctype, missing, codes)
+		return
+	}
+
+	debugLog("  [deploy-fanin] OK: %d %s deploys visible with identical code on %d nodes at finalized %d",
+		len(created), ctype, len(nodeKeys), finalizedHeight)
+}
+
 // ===========================================================================
 // Resource Stress Vectors
 //
@@ -532,8 +1154,8 @@ func DoGasGuzzler() {
 	c := rngChoice(contracts)
 	nodeName, node := pickNode()
 
-	// Random iterations: 500-10000 (each iteration ~36 gas for keccak256)
-	iterations := uint64(rngIntn(9500) + 500)
+	// Each iteration costs ~36 gas for keccak256
+	iterations := magnitudes.gasGuzzler.pick()
 
 	calldata, err := cborWrapCalldata(calcSelector("burnGas(uint256)"), encodeUint256(iterations))
 	if err != nil {
@@ -542,14 +1164,99 @@ func DoGasGuzzler() {
 	}
 
 	msgCid, ok := invokeContract(node, c.deployer, c.deployKI, c.addr, calldata, "gas-guzzler")
+	if ok {
+		recordContractCall(c.ctype)
+	}
 
 	debugLog("  [gas-guzzler] iterations=%d via %s ok=%v cid=%s",
 		iterations, nodeName, ok, cidStr(msgCid))
 }
 
+// blockGasLimit is the network's per-block gas ceiling (build.BlockGasLimit).
+// A single message whose GasLimit exceeds it can never fit in a block.
+const blockGasLimit = 10_000_000_000
+
+// DoBlockGasLimit signs one burnGas call sized to consume the whole block
+// gas budget, with a GasLimit above blockGasLimit, and pushes the identical
+// message to every node. Each node must refuse it at submission: a node that
+// admits it to its mempool has lost the block-gas-limit bound, which is
+// consensus-critical. The nonce is not advanced, since the message must
+// never execute.
+func DoBlockGasLimit() {
+	contracts := getContractsByType("gasguzzler")
+	if len(contracts) == 0 {
+		doDeployStressContract("gasguzzler")
+		return
+	}
+	c := rngChoice(contracts)
+
+	// ~36 gas per keccak iteration, so this burns the full block budget.
+	calldata, err := cborWrapCalldata(calcSelector("burnGas(uint256)"), encodeUint256(blockGasLimit/36))
+	if err != nil {
+		log.Printf("[block-gas-limit] cborWrap failed: %v", err)
+		return
+	}
+	msg := &types.Message{
+		From:       c.deployer,
+		To:         c.addr,
+		Nonce:      nonces[c.deployer],
+		Value:      abi.NewTokenAmount(0),
+		Method:     builtintypes.MethodsEVM.InvokeContract,
+		Params:     calldata,
+		GasLimit:   blockGasLimit + 1 + int64(rngIntn(blockGasLimit)),
+		GasFeeCap:  defaultGasFeeCap,
+		GasPremium: defaultGasPremium,
+	}
+	smsg := signMsg(msg, c.deployKI)
+	if smsg == nil {
+		return
+	}
+
+	for _, name := range nodeKeys {
+		_, err := mpoolPush(nodes[name], smsg)
+		if errors.Is(err, errDryRun) {
+			return
+		}
+		rejected := err != nil
+		alwaysAssert(rejected, aidBlockGasLimitRejected, map[string]any{
+			"node":            name,
+			"node_type":       nodeType(name),
+			"from":            msg.From.String(),
+			"nonce":           msg.Nonce,
+			"gas_limit":       msg.GasLimit,
+			"block_gas_limit": int64(blockGasLimit),
+			"msg_cid":         smsg.Cid().String(),
+			"error":           errStr(err),
+		})
+		if !rejected {
+			log.Printf("[block-gas-limit] SAFETY VIOLATION: %s accepted message %s with gas limit %d > %d",
+				name, cidStr(smsg.Cid()), msg.GasLimit, int64(blockGasLimit))
+			continue
+		}
+		debugLog("  [block-gas-limit] %s rejected gas limit %d: %v", name, msg.GasLimit, err)
+	}
+}
+
+// pendingLogBlast is a submitted blastLogs call awaiting receipt checks.
+type pendingLogBlast struct {
+	msgCid cid.Cid
+	node   string
+	count  uint64
+	epoch  abi.ChainEpoch // head height at submission
+}
+
+const maxPendingLogBlasts = 20
+
+// pendingLogBlasts is main-goroutine only.
+var pendingLogBlasts []pendingLogBlast
+
 // DoLogBlaster calls blastLogs(count) — emits massive numbers of events
 // to stress receipt storage, bloom filter computation, and event indexing.
+// Earlier calls are read back via EthGetTransactionReceipt to check that
+// every emitted event made it into the receipt.
 func DoLogBlaster() {
+	resolveLogBlasts()
+
 	contracts := getContractsByType("logblaster")
 	if len(contracts) == 0 {
 		doDeployStressContract("logblaster")
@@ -559,8 +1266,8 @@ func DoLogBlaster() {
 	c := rngChoice(contracts)
 	nodeName, node := pickNode()
 
-	// Random event count: 50-500 (each LOG2 costs ~1125 gas + data)
-	count := uint64(rngIntn(450) + 50)
+	// Each LOG2 costs ~1125 gas + data
+	count := magnitudes.logBlaster.pick()
 
 	calldata, err := cborWrapCalldata(calcSelector("blastLogs(uint256)"), encodeUint256(count))
 	if err != nil {
@@ -569,26 +1276,268 @@ func DoLogBlaster() {
 	}
 
 	msgCid, ok := invokeContract(node, c.deployer, c.deployKI, c.addr, calldata, "log-blaster")
+	if ok {
+		recordContractCall(c.ctype)
+		if len(pendingLogBlasts) < maxPendingLogBlasts {
+			pendingLogBlasts = append(pendingLogBlasts, pendingLogBlast{
+				msgCid: msgCid, node: nodeName, count: count, epoch: headEpoch(node),
+			})
+		}
+	}
 
 	debugLog("  [log-blaster] count=%d via %s ok=%v cid=%s",
 		count, nodeName, ok, cidStr(msgCid))
 }
 
-// DoMemoryBomb calls expandMemory(words) — allocates EVM memory with
-// quadratic cost growth. Targets node-side allocator and FVM memory accounting.
-func DoMemoryBomb() {
-	contracts := getContractsByType("memorybomb")
-	if len(contracts) == 0 {
-		doDeployStressContract("memorybomb")
-		return
-	}
-
-	c := rngChoice(contracts)
-	nodeName, node := pickNode()
-
-	// Random words: 100-5000 (memory cost grows quadratically)
-	words := uint64(rngIntn(4900) + 100)
-
+// resolveLogBlasts checks the Eth receipt of each executed blastLogs call:
+// a successful call must carry exactly count logs. Calls without a receipt
+// receiptLookback epochs after submission are dropped.
+func resolveLogBlasts() {
+	var remaining []pendingLogBlast
+	for _, pb := range pendingLogBlasts {
+		receipt, err := getEthReceipt(nodes[pb.node], pb.msgCid)
+		if err != nil || receipt == nil {
+			if age := headEpoch(nodes[pb.node]) - pb.epoch; age > receiptLookback {
+				log.Printf("[log-blaster] dropping cid=%s: no receipt %d epochs after submit", cidStr(pb.msgCid), age)
+				continue
+			}
+			remaining = append(remaining, pb)
+			continue
+		}
+		if receipt.Status != 1 {
+			debugLog("  [log-blaster] cid=%s reverted (status=%d), skipping log count", cidStr(pb.msgCid), receipt.Status)
+			continue
+		}
+
+		complete := uint64(len(receipt.Logs)) == pb.count
+		alwaysAssert(complete, aidLogBlasterReceiptLogs, map[string]any{
+			"node":      pb.node,
+			"node_type": nodeType(pb.node),
+			"msg_cid":   pb.msgCid.String(),
+			"tx_hash":   receipt.TransactionHash.String(),
+			"count":     pb.count,
+			"logs":      len(receipt.Logs),
+		})
+
+		if !complete {
+			log.Printf("[log-blaster] RECEIPT LOG MISMATCH on %s: cid=%s emitted=%d receipt_logs=%d",
+				pb.node, cidStr(pb.msgCid), pb.count, len(receipt.Logs))
+			continue
+		}
+		debugLog("  [log-blaster] OK: receipt for %s carries %d logs", cidStr(pb.msgCid), pb.count)
+
+		if len(receipt.Logs) > 0 && len(logFilterTargets) < maxLogFilterTargets {
+			logFilterTargets = append(logFilterTargets, logFilterTarget{
+				txHash:   receipt.TransactionHash,
+				block:    receipt.BlockNumber,
+				contract: receipt.Logs[0].Address,
+				count:    pb.count,
+			})
+		}
+	}
+	pendingLogBlasts = remaining
+}
+
+// ===========================================================================
+// DoEthLogFilter (Interop — Event Index and Log Filtering)
+//
+// Reads back a confirmed, finalized blastLogs call through EthGetLogs on
+// two nodes, filtering by the LogBlaster address and event topic over a
+// block range around the call's block, and sometimes also by the indexed
+// loop counter (topic 1). Logs from other blastLogs calls may fall in the
+// range, so only logs carrying our tx hash are counted against what the
+// call emitted; every returned log must satisfy the filter, and both
+// nodes must return identical results.
+// ===========================================================================
+
+// logBlastTopic is keccak256 of LogBlaster's event signature, its topic 0.
+var logBlastTopic = ethtypes.EthHash{
+	0x47, 0xdf, 0x3e, 0xf8, 0xf8, 0xbb, 0x56, 0x79, 0x03, 0xa8, 0xb7, 0x6f, 0x58, 0x75, 0x6a, 0x57,
+	0xfd, 0xac, 0xce, 0x2c, 0x4f, 0x7a, 0xfa, 0x10, 0xc4, 0xcb, 0x84, 0x88, 0x42, 0xbd, 0x77, 0x05,
+}
+
+// logFilterTarget is a blastLogs call whose receipt carried every log.
+type logFilterTarget struct {
+	txHash   ethtypes.EthHash
+	block    ethtypes.EthUint64
+	contract ethtypes.EthAddress
+	count    uint64
+}
+
+const (
+	maxLogFilterTargets = 20
+	logFilterWiden      = 3 // max epochs the range extends past the call's block on each side
+)
+
+// logFilterTargets is main-goroutine only.
+var logFilterTargets []logFilterTarget
+
+func DoEthLogFilter() {
+	if len(nodeKeys) < 2 {
+		return
+	}
+	finalizedHeight, _ := getFinalizedHeight()
+	if finalizedHeight < finalizedMinHeight {
+		return
+	}
+
+	idx := -1
+	for i, t := range logFilterTargets {
+		if abi.ChainEpoch(t.block) <= finalizedHeight {
+			idx = i
+			break
+		}
+	}
+	if idx < 0 {
+		return
+	}
+	t := logFilterTargets[idx]
+	logFilterTargets = append(logFilterTargets[:idx], logFilterTargets[idx+1:]...)
+
+	// Widen the range on either side, but never past finality.
+	from := t.block - ethtypes.EthUint64(rngIntn(logFilterWiden+1))
+	if from > t.block {
+		from = 0
+	}
+	to := t.block + ethtypes.EthUint64(rngIntn(logFilterWiden+1))
+	if to > ethtypes.EthUint64(finalizedHeight) {
+		to = ethtypes.EthUint64(finalizedHeight)
+	}
+	fromHex, toHex := from.Hex(), to.Hex()
+	spec := &ethtypes.EthFilterSpec{
+		FromBlock: &fromHex,
+		ToBlock:   &toHex,
+		Address:   ethtypes.EthAddressList{t.contract},
+		Topics:    ethtypes.EthTopicSpec{{logBlastTopic}},
+	}
+	expect := t.count
+	var counter *ethtypes.EthHash
+	if rngIntn(2) == 0 {
+		var k ethtypes.EthHash
+		copy(k[:], encodeUint256(uint64(rngIntn(int(t.count)))))
+		counter = &k
+		spec.Topics = append(spec.Topics, ethtypes.EthHashList{k})
+		expect = 1
+	}
+
+	nameA := rngChoice(nodeKeys)
+	nameB := rngChoice(nodeKeys)
+	for nameB == nameA {
+		nameB = rngChoice(nodeKeys)
+	}
+
+	var views [2][]string
+	for i, name := range []string{nameA, nameB} {
+		res, err := retryRPC(func() (*ethtypes.EthFilterResult, error) {
+			return nodes[name].EthGetLogs(ctx, spec)
+		})
+		if err != nil {
+			debugLog("  [log-filter] EthGetLogs failed for %s: %v", name, err)
+			return
+		}
+		logs, err := decodeEthLogs(res)
+		if err != nil {
+			log.Printf("[log-filter] cannot decode EthGetLogs result from %s: %v", name, err)
+			return
+		}
+
+		var ours uint64
+		var stray []string
+		for _, l := range logs {
+			if l.TransactionHash == t.txHash {
+				ours++
+			}
+			matches := l.Address == t.contract && !l.Removed &&
+				l.BlockNumber >= from && l.BlockNumber <= to &&
+				len(l.Topics) >= 1 && l.Topics[0] == logBlastTopic &&
+				(counter == nil || len(l.Topics) >= 2 && l.Topics[1] == *counter)
+			if !matches {
+				stray = append(stray, fmt.Sprintf("%s/%d@%d", l.TransactionHash, l.LogIndex, l.BlockNumber))
+			}
+			views[i] = append(views[i], fmt.Sprintf("%s/%d@%d:%s", l.TransactionHash, l.LogIndex, l.BlockNumber, l.Data))
+		}
+
+		details := map[string]any{
+			"node":         name,
+			"node_type":    nodeType(name),
+			"tx_hash":      t.txHash.String(),
+			"contract":     t.contract.String(),
+			"from_block":   uint64(from),
+			"to_block":     uint64(to),
+			"by_counter":   counter != nil,
+			"emitted":      t.count,
+			"expected":     expect,
+			"matched_ours": ours,
+			"returned":     len(logs),
+		}
+		complete := ours == expect
+		alwaysAssert(complete, aidLogFilterComplete, details)
+		if !complete {
+			log.Printf("[log-filter] COUNT MISMATCH on %s for tx %s in [%d,%d]: expected=%d got=%d",
+				name, t.txHash, from, to, expect, ours)
+		}
+
+		details["stray"] = stray
+		clean := len(stray) == 0
+		alwaysAssert(clean, aidLogFilterMatches, details)
+		if !clean {
+			log.Printf("[log-filter] %d logs outside filter on %s: %v", len(stray), name, stray)
+		}
+	}
+
+	agree := strings.Join(views[0], ",") == strings.Join(views[1], ",")
+	alwaysAssert(agree, aidLogFilterAgree, map[string]any{
+		"tx_hash":     t.txHash.String(),
+		"from_block":  uint64(from),
+		"to_block":    uint64(to),
+		"by_counter":  counter != nil,
+		"node_a":      nameA,
+		"node_a_impl": nodeImpl(nameA),
+		"node_b":      nameB,
+		"node_b_impl": nodeImpl(nameB),
+		"logs_a":      len(views[0]),
+		"logs_b":      len(views[1]),
+	})
+	if !agree {
+		log.Printf("[log-filter] RESULT DIVERGENCE for tx %s in [%d,%d] between %s (%d logs) and %s (%d logs)",
+			t.txHash, from, to, nameA, len(views[0]), nameB, len(views[1]))
+		return
+	}
+	debugLog("  [log-filter] OK: %s and %s agree on %d logs in [%d,%d]", nameA, nameB, len(views[0]), from, to)
+}
+
+// decodeEthLogs converts an EthGetLogs result, whose entries arrive as
+// generic JSON values over RPC, into EthLogs.
+func decodeEthLogs(res *ethtypes.EthFilterResult) ([]ethtypes.EthLog, error) {
+	if res == nil {
+		return nil, nil
+	}
+	raw, err := json.Marshal(res.Results)
+	if err != nil {
+		return nil, err
+	}
+	var logs []ethtypes.EthLog
+	if err := json.Unmarshal(raw, &logs); err != nil {
+		return nil, err
+	}
+	return logs, nil
+}
+
+// DoMemoryBomb calls expandMemory(words) — allocates EVM memory with
+// quadratic cost growth. Targets node-side allocator and FVM memory accounting.
+func DoMemoryBomb() {
+	contracts := getContractsByType("memorybomb")
+	if len(contracts) == 0 {
+		doDeployStressContract("memorybomb")
+		return
+	}
+
+	c := rngChoice(contracts)
+	nodeName, node := pickNode()
+
+	// Memory cost grows quadratically with words
+	words := magnitudes.memoryBomb.pick()
+
 	calldata, err := cborWrapCalldata(calcSelector("expandMemory(uint256)"), encodeUint256(words))
 	if err != nil {
 		log.Printf("[memory-bomb] cborWrap failed: %v", err)
@@ -596,15 +1545,40 @@ func DoMemoryBomb() {
 	}
 
 	msgCid, ok := invokeContract(node, c.deployer, c.deployKI, c.addr, calldata, "memory-bomb")
+	if ok {
+		recordContractCall(c.ctype)
+	}
 
 	debugLog("  [memory-bomb] words=%d via %s ok=%v cid=%s",
 		words, nodeName, ok, cidStr(msgCid))
 }
 
+// pendingStorageSpam is a submitted spamSlots call awaiting slot checks.
+type pendingStorageSpam struct {
+	msgCid   cid.Cid
+	node     string
+	contract address.Address
+	count    uint64
+	seed     uint64
+	epoch    abi.ChainEpoch // head height at submission
+}
+
+const (
+	maxPendingStorageSpams = 20
+	storageVerifySlots     = 8 // slots read back per resolved call
+)
+
+// pendingStorageSpams is main-goroutine only.
+var pendingStorageSpams []pendingStorageSpam
+
 // DoStorageSpam calls spamSlots(count, seed) — writes to many unique storage
 // slots per call. Each new SSTORE costs 20,000 gas. Stresses the HAMT
-// (state trie), SplitStore compaction, and snapshot size.
+// (state trie), SplitStore compaction, and snapshot size. Slot i of a call
+// is data[keccak256(i, seed)] = i+1, so earlier writes are read back with
+// EthGetStorageAt on two nodes to check they persisted.
 func DoStorageSpam() {
+	resolveStorageSpams()
+
 	contracts := getContractsByType("storagespam")
 	if len(contracts) == 0 {
 		doDeployStressContract("storagespam")
@@ -614,8 +1588,8 @@ func DoStorageSpam() {
 	c := rngChoice(contracts)
 	nodeName, node := pickNode()
 
-	// Random slot count: 10-200 (each SSTORE to new slot = 20k gas)
-	count := uint64(rngIntn(190) + 10)
+	// Each SSTORE to a new slot costs 20k gas
+	count := magnitudes.storageSpam.pick()
 	// Random seed so each call hits different slots
 	seed := random.GetRandom()
 
@@ -630,7 +1604,986 @@ func DoStorageSpam() {
 	}
 
 	msgCid, ok := invokeContract(node, c.deployer, c.deployKI, c.addr, calldata, "storage-spam")
+	if ok {
+		recordContractCall(c.ctype)
+		storageSlotsWritten += int(count)
+		if len(pendingStorageSpams) < maxPendingStorageSpams {
+			pendingStorageSpams = append(pendingStorageSpams, pendingStorageSpam{
+				msgCid: msgCid, node: nodeName, contract: c.addr, count: count, seed: seed,
+				epoch: headEpoch(node),
+			})
+		}
+	}
 
 	debugLog("  [storage-spam] count=%d seed=%d via %s ok=%v cid=%s",
 		count, seed, nodeName, ok, cidStr(msgCid))
 }
+
+// storageSpamSlot returns the storage position of data[keccak256(i, seed)],
+// with the mapping at slot 0 as in the StorageSpammer contract.
+func storageSpamSlot(i, seed uint64) []byte {
+	key := keccak256(encodeUint256(i), encodeUint256(seed))
+	return keccak256(key, encodeUint256(0))
+}
+
+// resolveStorageSpams reads back a sample of the slots written by each
+// executed spamSlots call on two nodes, at the call's block once that block
+// is final on both. Each slot must hold i+1 on both.
+func resolveStorageSpams() {
+	var remaining []pendingStorageSpam
+	for _, ps := range pendingStorageSpams {
+		receipt, err := getEthReceipt(nodes[ps.node], ps.msgCid)
+		if err != nil || receipt == nil {
+			if age := headEpoch(nodes[ps.node]) - ps.epoch; age > receiptLookback {
+				log.Printf("[storage-spam] dropping cid=%s: no receipt %d epochs after submit", cidStr(ps.msgCid), age)
+				continue
+			}
+			remaining = append(remaining, ps)
+			continue
+		}
+		if receipt.Status != 1 {
+			debugLog("  [storage-spam] cid=%s reverted (status=%d), skipping slot check", cidStr(ps.msgCid), receipt.Status)
+			continue
+		}
+
+		// Only read once the receipt's block is final on the submitting node
+		// and on a second node, so neither a reorg nor a lagging import can
+		// change what the slots hold.
+		height := abi.ChainEpoch(receipt.BlockNumber)
+		ready := func(name string) bool {
+			ts, err := finalizedTipSet(name)
+			return err == nil && ts.Height() >= height
+		}
+		if !ready(ps.node) {
+			remaining = append(remaining, ps)
+			continue
+		}
+		other := ps.node
+		if len(nodeKeys) > 1 {
+			other = ""
+			start := rngIntn(len(nodeKeys))
+			for j := range nodeKeys {
+				if name := nodeKeys[(start+j)%len(nodeKeys)]; name != ps.node && ready(name) {
+					other = name
+					break
+				}
+			}
+			if other == "" {
+				remaining = append(remaining, ps)
+				continue
+			}
+		}
+
+		blk := ethtypes.NewEthBlockNumberOrHashFromNumber(receipt.BlockNumber)
+		for k := 0; k < storageVerifySlots && uint64(k) < ps.count; k++ {
+			i := uint64(rngIntn(int(ps.count)))
+			slot := storageSpamSlot(i, ps.seed)
+			valA, errA := getStorageAt(nodes[ps.node], ps.contract, slot, blk)
+			valB, errB := getStorageAt(nodes[other], ps.contract, slot, blk)
+			if errA != nil || errB != nil {
+				log.Printf("[storage-spam] EthGetStorageAt failed: %s=%v %s=%v", ps.node, errA, other, errB)
+				break
+			}
+
+			expected := encodeUint256(i + 1)
+			details := map[string]any{
+				"contract": ps.contract.String(),
+				"msg_cid":  ps.msgCid.String(),
+				"seed":     ps.seed,
+				"index":    i,
+				"slot":     hex.EncodeToString(slot),
+				"node_a":   ps.node,
+				"value_a":  hex.EncodeToString(valA),
+				"node_b":   other,
+				"value_b":  hex.EncodeToString(valB),
+				"expected": i + 1,
+				"block":    uint64(receipt.BlockNumber),
+			}
+			persisted := bytes.Equal(valA, expected)
+			agree := bytes.Equal(valA, valB)
+			alwaysAssert(persisted, aidStorageSpamSlotPersisted, details)
+			alwaysAssert(agree, aidStorageSpamSlotAgree, details)
+
+			if !persisted || !agree {
+				log.Printf("[storage-spam] SLOT MISMATCH %s index=%d: %s=%x %s=%x expected=%d",
+					ps.contract, i, ps.node, valA, other, valB, i+1)
+				break
+			}
+		}
+		debugLog("  [storage-spam] verified slots of %s (seed=%d) on %s and %s", cidStr(ps.msgCid), ps.seed, ps.node, other)
+	}
+	pendingStorageSpams = remaining
+}
+
+// ===========================================================================
+// DoStateGrowthMonitor (Resource Safety — Bounded State Growth)
+//
+// Samples the finalized state root and measures how many bytes of new state
+// were added since the previous sample (ChainStatObj diff). Growth should
+// track the storage writes the engine issued plus a per-epoch allowance for
+// ordinary traffic; runaway growth points at a state-tree or blockstore leak.
+// The growth rate is reported in the engine metrics so disk-pressure failures
+// can be correlated with storage spam intensity.
+// ===========================================================================
+
+const (
+	stateGrowthMaxGap      = 100     // epochs; larger gaps just re-baseline
+	stateGrowthEpochBudget = 1 << 20 // bytes of new state allowed per epoch
+	stateGrowthSlotBudget  = 4 << 10 // bytes of new state allowed per slot written
+)
+
+// storageSlotsWritten counts storage slots submitted by DoStorageSpam.
+// Main goroutine only.
+var storageSlotsWritten int
+
+type stateGrowthSample struct {
+	height abi.ChainEpoch
+	root   cid.Cid
+	slots  int
+}
+
+var (
+	lastGrowthSample *stateGrowthSample
+	stateGrowthRate  float64 // bytes per epoch over the last sampled window
+)
+
+func DoStateGrowthMonitor() {
+	nodeName, node, ok := pickNodeWith("ChainStatObj")
+	if !ok {
+		return
+	}
+
+	height, tsk := getFinalizedHeight()
+	if height < finalizedMinHeight {
+		return
+	}
+	ts, err := node.ChainGetTipSet(ctx, tsk)
+	if err != nil {
+		log.Printf("[state-growth] ChainGetTipSet failed for %s: %v", nodeName, err)
+		return
+	}
+	sample := &stateGrowthSample{height: height, root: ts.ParentState(), slots: storageSlotsWritten}
+
+	prev := lastGrowthSample
+	if prev == nil || height <= prev.height || height-prev.height > stateGrowthMaxGap {
+		lastGrowthSample = sample
+		return
+	}
+
+	stat, err := node.ChainStatObj(ctx, sample.root, prev.root)
+	if err != nil {
+		log.Printf("[state-growth] ChainStatObj failed for %s: %v", nodeName, err)
+		return
+	}
+	lastGrowthSample = sample
+
+	epochs := int64(height - prev.height)
+	slots := int64(sample.slots - prev.slots)
+	budget := uint64(stateGrowthEpochBudget*epochs + stateGrowthSlotBudget*slots)
+	stateGrowthRate = float64(stat.Size) / float64(epochs)
+
+	bounded := stat.Size <= budget
+	sometimesAssert(bounded, aidStateGrowthProportional, map[string]any{
+		"node":        nodeName,
+		"from_height": prev.height,
+		"to_height":   height,
+		"added_bytes": stat.Size,
+		"added_links": stat.Links,
+		"slots":       slots,
+		"budget":      budget,
+	})
+
+	if !bounded {
+		log.Printf("[state-growth] state grew %d bytes over %d epochs with %d slots written (budget %d)",
+			stat.Size, epochs, slots, budget)
+	}
+
+	debugLog("  [state-growth] %d bytes over [%d,%d] (%.0f B/epoch, %d slots) via %s",
+		stat.Size, prev.height, height, stateGrowthRate, slots, nodeName)
+}
+
+// ===========================================================================
+// DoSimpleCoinMonitor (FVM Correctness — Token Conservation)
+//
+// SimpleCoin mints a fixed supply to its deployer (tx.origin) and only ever
+// moves it with sendCoin. Sums getBalance over every possible holder at the
+// finalized height and asserts it equals the minted supply, catching
+// double-credit or lost-funds bugs in FVM storage.
+//
+// Holders: every sendCoin in the engine is sent by the deployer, whose EVM
+// identity is its ID-masked eth address, and credits the recipient's raw f1
+// payload bytes. So the deployer's masked address plus the payload of every
+// keystore wallet covers all non-zero balances.
+// ===========================================================================
+
+const (
+	simpleCoinSupply       = 10_000 // minted to tx.origin by the constructor
+	simpleCoinMaxContracts = 3      // contracts audited per invocation
+)
+
+func DoSimpleCoinMonitor() {
+	contracts := getContractsByType("simplecoin")
+	if len(contracts) == 0 {
+		return
+	}
+	finalizedHeight, finTsk := getFinalizedHeight()
+	if finalizedHeight < finalizedMinHeight {
+		return
+	}
+	nodeName, node := pickNode()
+	blk := ethtypes.NewEthBlockNumberOrHashFromNumber(ethtypes.EthUint64(finalizedHeight))
+
+	for i := 0; i < simpleCoinMaxContracts && i < len(contracts); i++ {
+		c := rngChoice(contracts)
+
+		contractEth, err := ethtypes.EthAddressFromFilecoinAddress(c.addr)
+		if err != nil {
+			continue
+		}
+		deployerID, err := node.StateLookupID(ctx, c.deployer, finTsk)
+		if err != nil {
+			continue
+		}
+		deployerEth, err := ethtypes.EthAddressFromFilecoinAddress(deployerID)
+		if err != nil {
+			continue
+		}
+
+		holders := [][]byte{deployerEth[:]}
+		for _, a := range addrs {
+			holders = append(holders, a.Payload())
+		}
+
+		total := new(big.Int)
+		confirmed := true
+		for _, h := range holders {
+			calldata := append(calcSelector("getBalance(address)"), encodeAddress(h)...)
+			ret, err := node.EthCall(ctx, ethtypes.EthCall{To: &contractEth, Data: calldata}, blk)
+			if err != nil || len(ret) != 32 {
+				// Not deployed yet at the finalized height, or a null round.
+				confirmed = false
+				break
+			}
+			total.Add(total, new(big.Int).SetBytes(ret))
+		}
+		if !confirmed {
+			debugLog("  [simplecoin-monitor] skipping %s: not readable at finalized height %d", c.addr, finalizedHeight)
+			continue
+		}
+
+		conserved := total.Cmp(big.NewInt(simpleCoinSupply)) == 0
+		alwaysAssert(conserved, aidSimpleCoinConserved, map[string]any{
+			"node":         nodeName,
+			"node_type":    nodeType(nodeName),
+			"contract":     c.addr.String(),
+			"finalized_at": finalizedHeight,
+			"holders":      len(holders),
+			"total":        total.String(),
+			"supply":       simpleCoinSupply,
+		})
+
+		if !conserved {
+			log.Printf("[simplecoin-monitor] SUPPLY MISMATCH for %s at %d via %s: total=%s supply=%d",
+				c.addr, finalizedHeight, nodeName, total, simpleCoinSupply)
+			continue
+		}
+		debugLog("  [simplecoin-monitor] OK: %s conserves %d tokens over %d holders", c.addr, simpleCoinSupply, len(holders))
+	}
+}
+
+// ===========================================================================
+// DoCreate2Spam (FVM Stress — Deterministic CREATE2 Addresses)
+//
+// Calls the create2factory contract's deploy(salt) with random salts. Each
+// child lands at keccak256(0xff ++ factory ++ salt ++ keccak256(initcode))
+// [12:], which the factory reports in a Deployed(address) event; the event
+// must match the locally predicted address. Sometimes the same salt is sent
+// twice: the second CREATE2 collides and must revert identically on every
+// node. Stresses EAM.Create2 address derivation and collision handling.
+// ===========================================================================
+
+const (
+	create2Burst       = 4  // children per invocation
+	maxPendingCreate2s = 40 // receipts awaiting verification
+)
+
+// create2ChildInitcode is the initcode embedded in create2factory; it
+// deploys a contract whose runtime code is a single STOP.
+var create2ChildInitcode = []byte{0x60, 0x01, 0x60, 0x0c, 0x60, 0x00, 0x39, 0x60, 0x01, 0x60, 0x00, 0xf3, 0x00}
+
+// create2DeployedTopic is keccak256("Deployed(address)").
+var create2DeployedTopic = keccak256([]byte("Deployed(address)"))
+
+type pendingCreate2 struct {
+	msgCid    cid.Cid
+	node      string
+	salt      [32]byte
+	predicted ethtypes.EthAddress
+	firstCid  cid.Cid        // for a duplicate salt, the original deploy; cid.Undef otherwise
+	epoch     abi.ChainEpoch // head height at submission
+}
+
+// pendingCreate2s is main-goroutine only.
+var pendingCreate2s []pendingCreate2
+
+// predictCreate2 computes the CREATE2 address for factory, salt and the
+// child's initcode.
+func predictCreate2(factory ethtypes.EthAddress, salt [32]byte, initcode []byte) ethtypes.EthAddress {
+	h := keccak256([]byte{0xff}, factory[:], salt[:], keccak256(initcode))
+	var addr ethtypes.EthAddress
+	copy(addr[:], h[12:])
+	return addr
+}
+
+func DoCreate2Spam() {
+	resolveCreate2s()
+
+	contracts := getContractsByType("create2factory")
+	if len(contracts) == 0 {
+		doDeployStressContract("create2factory")
+		return
+	}
+	c := rngChoice(contracts)
+	nodeName, node := pickNode()
+
+	// The factory's own eth address (f410) is what CREATE2 hashes.
+	act, err := node.StateGetActor(ctx, c.addr, types.EmptyTSK)
+	if err != nil || act.DelegatedAddress == nil {
+		log.Printf("[create2-spam] cannot resolve eth address of factory %s via %s: %v", c.addr, nodeName, err)
+		return
+	}
+	factory, err := ethtypes.EthAddressFromFilecoinAddress(*act.DelegatedAddress)
+	if err != nil {
+		return
+	}
+
+	epoch := headEpoch(node)
+	var lastSalt [32]byte
+	var lastCid cid.Cid
+	for i := 0; i < create2Burst; i++ {
+		salt := lastSalt
+		dup := i > 0 && rngIntn(4) == 0
+		if !dup {
+			salt = randomSalt()
+		}
+
+		calldata, err := cborWrapCalldata(calcSelector("deploy(bytes32)"), salt[:])
+		if err != nil {
+			return
+		}
+		msgCid, ok := invokeContract(node, c.deployer, c.deployKI, c.addr, calldata, "create2-spam")
+		if !ok {
+			return
+		}
+		recordContractCall(c.ctype)
+
+		pc := pendingCreate2{msgCid: msgCid, node: nodeName, salt: salt, predicted: predictCreate2(factory, salt, create2ChildInitcode), epoch: epoch}
+		if dup {
+			pc.firstCid = lastCid
+		}
+		if len(pendingCreate2s) < maxPendingCreate2s {
+			pendingCreate2s = append(pendingCreate2s, pc)
+		}
+		debugLog("  [create2-spam] salt=%x dup=%v predicted=%s via %s cid=%s",
+			salt[:4], dup, pc.predicted, nodeName, cidStr(msgCid))
+
+		if !dup {
+			lastSalt, lastCid = salt, msgCid
+		}
+	}
+}
+
+// resolveCreate2s verifies executed CREATE2 deploys: a fresh salt's Deployed
+// event must carry the predicted address, and a repeated salt must revert
+// with the same exit code on every node. Entries still unresolved
+// receiptLookback epochs after submission are dropped.
+func resolveCreate2s() {
+	var remaining []pendingCreate2
+	keep := func(pc pendingCreate2) {
+		if age := headEpoch(nodes[pc.node]) - pc.epoch; age > receiptLookback {
+			log.Printf("[create2-spam] dropping cid=%s: unresolved %d epochs after submit", cidStr(pc.msgCid), age)
+			return
+		}
+		remaining = append(remaining, pc)
+	}
+	for _, pc := range pendingCreate2s {
+		if pc.firstCid.Defined() {
+			if !verifyCreate2Collision(pc) {
+				keep(pc)
+			}
+			continue
+		}
+
+		receipt, err := getEthReceipt(nodes[pc.node], pc.msgCid)
+		if err != nil || receipt == nil {
+			keep(pc)
+			continue
+		}
+		if receipt.Status != 1 {
+			log.Printf("[create2-spam] fresh-salt deploy %s reverted (status=%d)", cidStr(pc.msgCid), receipt.Status)
+			continue
+		}
+
+		var emitted *ethtypes.EthAddress
+		for _, l := range receipt.Logs {
+			if len(l.Topics) == 1 && bytes.Equal(l.Topics[0][:], create2DeployedTopic) && len(l.Data) == 32 {
+				var a ethtypes.EthAddress
+				copy(a[:], l.Data[12:])
+				emitted = &a
+				break
+			}
+		}
+		if emitted == nil {
+			log.Printf("[create2-spam] no Deployed event in receipt for %s", cidStr(pc.msgCid))
+			continue
+		}
+
+		matches := *emitted == pc.predicted
+		alwaysAssert(matches, aidCreate2AddressPredicted, map[string]any{
+			"node":      pc.node,
+			"node_type": nodeType(pc.node),
+			"msg_cid":   pc.msgCid.String(),
+			"salt":      ethtypes.EthBytes(pc.salt[:]).String(),
+			"predicted": pc.predicted.String(),
+			"emitted":   emitted.String(),
+		})
+		if !matches {
+			log.Printf("[create2-spam] ADDRESS MISMATCH for %s: predicted=%s emitted=%s",
+				cidStr(pc.msgCid), pc.predicted, emitted)
+		}
+	}
+	pendingCreate2s = remaining
+}
+
+// verifyCreate2Collision checks a duplicate-salt deploy. Returns false while
+// either message is still unexecuted.
+func verifyCreate2Collision(pc pendingCreate2) bool {
+	first, err := nodes[pc.node].StateSearchMsg(ctx, types.EmptyTSK, pc.firstCid, receiptLookback, true)
+	if err != nil || first == nil {
+		return false
+	}
+	if !first.Receipt.ExitCode.IsSuccess() {
+		return true // original never deployed, nothing to collide with
+	}
+
+	codes := make(map[string][]string) // exit code -> []nodeName
+	for _, name := range nodeKeys {
+		result, err := nodes[name].StateSearchMsg(ctx, types.EmptyTSK, pc.msgCid, receiptLookback, true)
+		if err != nil || result == nil {
+			continue
+		}
+		key := result.Receipt.ExitCode.String()
+		codes[key] = append(codes[key], name)
+	}
+	if len(codes) == 0 {
+		return false
+	}
+
+	reverted := len(codes) == 1 && len(codes[exitcode.Ok.String()]) == 0
+	alwaysAssert(reverted, aidCreate2CollisionReverts, withDivergence(codes, map[string]any{
+		"msg_cid":    pc.msgCid.String(),
+		"first_cid":  pc.firstCid.String(),
+		"salt":       ethtypes.EthBytes(pc.salt[:]).String(),
+		"exit_codes": codes,
+	}))
+	if !reverted {
+		log.Printf("[create2-spam] SALT COLLISION NOT REVERTED for %s: %v", cidStr(pc.msgCid), codes)
+	}
+	return true
+}
+
+// randomSalt draws a fresh 32-byte CREATE2 salt.
+func randomSalt() [32]byte {
+	var salt [32]byte
+	for j := 0; j < 4; j++ {
+		v := random.GetRandom()
+		for k := 0; k < 8; k++ {
+			salt[j*8+k] = byte(v >> (8 * k))
+		}
+	}
+	return salt
+}
+
+// ===========================================================================
+// DoSelfDestructRecreate (Actor Lifecycle Stress — Address Reuse)
+//
+// CREATE2s a SelfDestruct child through the recreatefactory contract with a
+// random salt, destroys it, then CREATE2s again with the same salt. Whether
+// a destroyed address may be reused is the FVM's call, but every node must
+// reach the same verdict and hold the same actor at the redeploy's tipset.
+// When the redeploy succeeds, the new child must sit at the original
+// address and serve the original runtime code.
+// ===========================================================================
+
+func DoSelfDestructRecreate() {
+	contracts := getContractsByType("recreatefactory")
+	if len(contracts) == 0 {
+		doDeployStressContract("recreatefactory")
+		return
+	}
+	c := rngChoice(contracts)
+	nodeName, node := pickNode()
+
+	act, err := node.StateGetActor(ctx, c.addr, types.EmptyTSK)
+	if err != nil || act.DelegatedAddress == nil {
+		log.Printf("[selfdestruct-recreate] cannot resolve eth address of factory %s via %s: %v", c.addr, nodeName, err)
+		return
+	}
+	factory, err := ethtypes.EthAddressFromFilecoinAddress(*act.DelegatedAddress)
+	if err != nil {
+		return
+	}
+
+	salt := randomSalt()
+	predicted := predictCreate2(factory, salt, contractBytecodes["selfdestruct"])
+	childAddr, err := predicted.ToFilecoinAddress()
+	if err != nil {
+		return
+	}
+	deployData, err := cborWrapCalldata(calcSelector("deploy(bytes32)"), salt[:])
+	if err != nil {
+		return
+	}
+	destroyData, err := cborWrapCalldata(calcSelector("destroy()"))
+	if err != nil {
+		return
+	}
+
+	// Step 1: create the child and capture the code it serves.
+	first, ok := recreateStep(node, c, c.addr, c.ctype, deployData, "deploy")
+	if !ok {
+		return
+	}
+	if !first.Receipt.ExitCode.IsSuccess() {
+		log.Printf("[selfdestruct-recreate] initial deploy failed with exit code %d", first.Receipt.ExitCode)
+		return
+	}
+	origCode, err := node.EthGetCode(ctx, predicted, ethtypes.NewEthBlockNumberOrHashFromNumber(ethtypes.EthUint64(first.Height)))
+	if err != nil || len(origCode) == 0 {
+		log.Printf("[selfdestruct-recreate] no code at predicted %s after deploy via %s: %v", predicted, nodeName, err)
+		return
+	}
+
+	// Step 2: destroy it.
+	destroyed, ok := recreateStep(node, c, childAddr, "selfdestruct", destroyData, "destroy")
+	if !ok {
+		return
+	}
+	if !destroyed.Receipt.ExitCode.IsSuccess() {
+		log.Printf("[selfdestruct-recreate] destroy failed with exit code %d", destroyed.Receipt.ExitCode)
+		return
+	}
+
+	// Step 3: CREATE2 again with the same salt.
+	again, ok := recreateStep(node, c, c.addr, c.ctype, deployData, "redeploy")
+	if !ok {
+		return
+	}
+	recreated := again.Receipt.ExitCode.IsSuccess()
+	debugLog("  [selfdestruct-recreate] salt=%x child=%s recreated=%v exit=%s via %s",
+		salt[:4], predicted, recreated, again.Receipt.ExitCode, nodeName)
+
+	if recreated {
+		var ret abi.CborBytes
+		if err := ret.UnmarshalCBOR(bytes.NewReader(again.Receipt.Return)); err != nil || len(ret) != 32 {
+			log.Printf("[selfdestruct-recreate] cannot decode redeploy return %x: %v", again.Receipt.Return, err)
+			return
+		}
+		var got ethtypes.EthAddress
+		copy(got[:], ret[12:])
+
+		sameAddr := got == predicted
+		alwaysAssert(sameAddr, aidRecreateSameAddress, map[string]any{
+			"node":      nodeName,
+			"node_type": nodeType(nodeName),
+			"salt":      ethtypes.EthBytes(salt[:]).String(),
+			"predicted": predicted.String(),
+			"returned":  got.String(),
+		})
+		if !sameAddr {
+			log.Printf("[selfdestruct-recreate] ADDRESS MISMATCH: predicted=%s returned=%s", predicted, got)
+		}
+
+		code, err := node.EthGetCode(ctx, predicted, ethtypes.NewEthBlockNumberOrHashFromNumber(ethtypes.EthUint64(again.Height)))
+		if err == nil {
+			sameCode := bytes.Equal(code, origCode)
+			alwaysAssert(sameCode, aidRecreateCodeRestored, map[string]any{
+				"node":      nodeName,
+				"node_type": nodeType(nodeName),
+				"child":     predicted.String(),
+				"orig_len":  len(origCode),
+				"code_len":  len(code),
+			})
+			if !sameCode {
+				log.Printf("[selfdestruct-recreate] CODE MISMATCH at %s: %d bytes before, %d after", predicted, len(origCode), len(code))
+			}
+		}
+	}
+
+	verifyRecreateAgreement(again, childAddr, predicted)
+}
+
+// recreateStep sends calldata to `to` (a contract of type ctype) from the
+// factory's deployer and waits for it to execute on node.
+func recreateStep(node api.FullNode, c deployedContract, to address.Address, ctype string, calldata []byte, step string) (*api.MsgLookup, bool) {
+	msgCid, ok := invokeContract(node, c.deployer, c.deployKI, to, calldata, "selfdestruct-recreate-"+step)
+	if !ok {
+		return nil, false
+	}
+	recordContractCall(ctype)
+
+	waitCtx, waitCancel := context.WithTimeout(ctx, stateWaitTimeout)
+	defer waitCancel()
+	result, err := node.StateWaitMsg(waitCtx, msgCid, 1, 200, false)
+	if err != nil {
+		log.Printf("[selfdestruct-recreate] %s StateWaitMsg failed: %v", step, err)
+		return nil, false
+	}
+	return result, true
+}
+
+// verifyRecreateAgreement checks that every node reports the same redeploy
+// exit code and the same child actor at the redeploy's tipset. Nodes that
+// fail to answer are skipped: an RPC error is not a state disagreement.
+func verifyRecreateAgreement(again *api.MsgLookup, childAddr address.Address, child ethtypes.EthAddress) {
+	if len(nodeKeys) < 2 {
+		return
+	}
+
+	states := make(map[string][]string) // exit code + actor state -> []nodeName
+	for _, name := range nodeKeys {
+		lookup, err := nodes[name].StateSearchMsg(ctx, again.TipSet, again.Message, 100, true)
+		if err != nil || lookup == nil {
+			continue
+		}
+		actor, err := nodes[name].StateGetActor(ctx, childAddr, again.TipSet)
+		key := "exit=" + lookup.Receipt.ExitCode.String()
+		switch {
+		case err == nil && actor != nil:
+			key += fmt.Sprintf(" code=%s head=%s nonce=%d balance=%s", actor.Code, actor.Head, actor.Nonce, actor.Balance)
+		case err != nil && strings.Contains(err.Error(), "actor not found"):
+			key += " actor=absent"
+		default:
+			continue
+		}
+		states[key] = append(states[key], name)
+	}
+	if len(states) == 0 {
+		return
+	}
+
+	agree := len(states) == 1
+	alwaysAssert(agree, aidRecreateStateAgree, withDivergence(states, map[string]any{
+		"msg_cid": again.Message.String(),
+		"child":   child.String(),
+		"tipset":  again.TipSet.String(),
+		"states":  states,
+	}))
+	if !agree {
+		log.Printf("[selfdestruct-recreate] STATE DIVERGENCE for %s: %v", child, states)
+	}
+}
+
+// ===========================================================================
+// DoRevertReason (FVM Stress — Revert Return Data)
+//
+// Calls revertWithReason(reason) with a random string via EthCall on every
+// node at the finalized height. The call must revert with Error(string)
+// data that decodes back to the same reason, and every node must return
+// byte-identical revert data. Exit-code checks elsewhere never look at the
+// return-data path on failure.
+// ===========================================================================
+
+// errorStringSelector is the selector of Solidity's Error(string).
+var errorStringSelector = []byte{0x08, 0xc3, 0x79, 0xa0}
+
+const revertReasonMaxLen = 96
+
+func DoRevertReason() {
+	contracts := getContractsByType("revertreason")
+	if len(contracts) == 0 {
+		doDeployStressContract("revertreason")
+		return
+	}
+	finalizedHeight, _ := getFinalizedHeight()
+	if finalizedHeight < finalizedMinHeight {
+		return
+	}
+
+	c := rngChoice(contracts)
+	contractEth, err := ethtypes.EthAddressFromFilecoinAddress(c.addr)
+	if err != nil {
+		return
+	}
+	const alphabet = "abcdefghijklmnopqrstuvwxyz0123456789 -_"
+	reason := make([]byte, 1+rngIntn(revertReasonMaxLen))
+	for i := range reason {
+		reason[i] = alphabet[rngIntn(len(alphabet))]
+	}
+	calldata := append(calcSelector("revertWithReason(string)"), encodeString(string(reason))...)
+	blk := ethtypes.NewEthBlockNumberOrHashFromNumber(ethtypes.EthUint64(finalizedHeight))
+
+	results := make(map[string][]string) // revert data (or outcome) -> []nodeName
+	reverted := 0
+	for _, name := range nodeKeys {
+		_, err := retryRPC(func() (ethtypes.EthBytes, error) {
+			return nodes[name].EthCall(ctx, ethtypes.EthCall{To: &contractEth, Data: calldata}, blk)
+		})
+
+		var rev *api.ErrExecutionReverted
+		var key string
+		switch {
+		case errors.As(err, &rev):
+			key = rev.Data
+			reverted++
+		case err == nil:
+			key = "no-revert"
+		case isTransientRPCError(err):
+			debugLog("  [revert-reason] EthCall failed for %s: %v", name, err)
+			continue
+		default:
+			key = "error: " + err.Error()
+		}
+		results[key] = append(results[key], name)
+	}
+	if reverted == 0 {
+		// Not deployed yet at the finalized height: calls to an empty
+		// address succeed with no data.
+		debugLog("  [revert-reason] %s not callable at finalized height %d", c.addr, finalizedHeight)
+		return
+	}
+
+	for data, names := range results {
+		got, ok := "", false
+		if raw, err := hex.DecodeString(strings.TrimPrefix(data, "0x")); err == nil {
+			got, ok = decodeRevertReason(raw)
+		}
+		matches := ok && got == string(reason)
+		alwaysAssert(matches, aidRevertReasonDecoded, map[string]any{
+			"nodes":    names,
+			"contract": c.addr.String(),
+			"reason":   string(reason),
+			"decoded":  got,
+			"data":     data,
+		})
+		if !matches {
+			log.Printf("[revert-reason] %v returned %q for reason %q", names, data, reason)
+		}
+	}
+
+	deterministic := len(results) == 1
+	alwaysAssert(deterministic, aidRevertReasonDeterministic, withDivergence(results, map[string]any{
+		"contract":     c.addr.String(),
+		"finalized_at": finalizedHeight,
+		"reason":       string(reason),
+		"results":      results,
+	}))
+
+	if !deterministic {
+		log.Printf("[revert-reason] DIVERGENCE for %s at %d: %v", c.addr, finalizedHeight, results)
+		return
+	}
+	recordContractCall(c.ctype)
+	debugLog("  [revert-reason] OK: %d nodes reverted with %q", reverted, reason)
+}
+
+// decodeRevertReason extracts the message from Error(string) revert data.
+func decodeRevertReason(data []byte) (string, bool) {
+	if len(data) < 4+64 || !bytes.Equal(data[:4], errorStringSelector) {
+		return "", false
+	}
+	args := data[4:]
+	offset := new(big.Int).SetBytes(args[:32])
+	if !offset.IsUint64() || offset.Uint64()+32 > uint64(len(args)) {
+		return "", false
+	}
+	lenAt := offset.Uint64()
+	length := new(big.Int).SetBytes(args[lenAt : lenAt+32])
+	if !length.IsUint64() || lenAt+32+length.Uint64() > uint64(len(args)) {
+		return "", false
+	}
+	return string(args[lenAt+32 : lenAt+32+length.Uint64()]), true
+}
+
+// ===========================================================================
+// DoReentrancy (FVM Stress — Cross-Contract Reentrancy)
+//
+// Drives a deliberately vulnerable bank (pays out before zeroing the
+// caller's balance) with honest deposits and with attack() calls from an
+// attacker contract whose fallback re-enters withdraw(). Each run first
+// checks a bank and an attacker at the finalized height on every node:
+// the bank's balance must equal its ledger total and the attacker's
+// balance must equal the value its fallback received, so however deep the
+// re-entry goes no funds are created, and every node must read the same
+// state. Exercises nested value-carrying EVM calls and their revert
+// rollback inside a single message.
+// ===========================================================================
+
+// reentrancyUnit is the deposit and attack stake granularity (0.001 FIL).
+const reentrancyUnit = 1_000_000_000_000_000
+
+// reentrancyState is one node's view of a bank/attacker pair.
+type reentrancyState struct {
+	bankBalance     *big.Int
+	bankTotal       *big.Int // bank slot 0
+	attackerBalance *big.Int
+	received        *big.Int // attacker slot 2
+	staked          *big.Int // attacker slot 3
+}
+
+func (s reentrancyState) String() string {
+	return strings.Join([]string{
+		s.bankBalance.String(), s.bankTotal.String(),
+		s.attackerBalance.String(), s.received.String(), s.staked.String(),
+	}, "/")
+}
+
+func DoReentrancy() {
+	banks := getContractsByType("reentrancybank")
+	if len(banks) == 0 {
+		doDeployStressContract("reentrancybank")
+		return
+	}
+	attackers := getContractsByType("reentrancyattacker")
+	if len(attackers) == 0 {
+		doDeployStressContract("reentrancyattacker")
+		return
+	}
+
+	bank := rngChoice(banks)
+	attacker := rngChoice(attackers)
+	checkReentrancyState(bank, attacker)
+
+	bankEth, err := ethtypes.EthAddressFromFilecoinAddress(bank.addr)
+	if err != nil {
+		return
+	}
+
+	// Honest deposits give the attacker something to drain.
+	target, tag := bank, "reentrancy-deposit"
+	calldata, err := cborWrapCalldata(calcSelector("deposit()"))
+	if rngIntn(2) == 0 {
+		target, tag = attacker, "reentrancy-attack"
+		calldata, err = cborWrapCalldata(calcSelector("attack(address)"), encodeAddress(bankEth[:]))
+	}
+	if err != nil {
+		log.Printf("[reentrancy] cborWrap failed: %v", err)
+		return
+	}
+
+	fromAddr, fromKI := pickWallet()
+	nodeName, node := pickNode()
+	msg := &types.Message{
+		From:   fromAddr,
+		To:     target.addr,
+		Value:  abi.NewTokenAmount(int64(1+rngIntn(10)) * reentrancyUnit),
+		Method: builtintypes.MethodsEVM.InvokeContract,
+		Params: calldata,
+	}
+	msgCid, ok := pushContractMsg(node, msg, fromKI, tag)
+	if ok {
+		recordContractCall(target.ctype)
+	}
+
+	debugLog("  [reentrancy] %s bank=%s value=%s via %s ok=%v cid=%s",
+		tag, bank.addr, msg.Value, nodeName, ok, cidStr(msgCid))
+}
+
+// checkReentrancyState reads the bank and attacker on every node at the
+// finalized height and asserts the balance invariants and cross-node
+// agreement.
+func checkReentrancyState(bank, attacker deployedContract) {
+	finalizedHeight, _ := getFinalizedHeight()
+	if finalizedHeight < finalizedMinHeight {
+		return
+	}
+	blk := ethtypes.NewEthBlockNumberOrHashFromNumber(ethtypes.EthUint64(finalizedHeight))
+
+	results := make(map[string][]string) // state -> []nodeName
+	for _, name := range nodeKeys {
+		st, err := readReentrancyState(nodes[name], bank.addr, attacker.addr, blk)
+		if err != nil {
+			debugLog("  [reentrancy] state read failed for %s: %v", name, err)
+			continue
+		}
+		details := map[string]any{
+			"node":             name,
+			"bank":             bank.addr.String(),
+			"attacker":         attacker.addr.String(),
+			"finalized_at":     finalizedHeight,
+			"bank_balance":     st.bankBalance.String(),
+			"bank_total":       st.bankTotal.String(),
+			"attacker_balance": st.attackerBalance.String(),
+			"received":         st.received.String(),
+			"staked":           st.staked.String(),
+		}
+		bankOK := st.bankBalance.Cmp(st.bankTotal) == 0
+		attackerOK := st.attackerBalance.Cmp(st.received) == 0
+		alwaysAssert(bankOK, aidReentrancyBankSolvent, details)
+		alwaysAssert(attackerOK, aidReentrancyNoFundsCreated, details)
+		sometimesAssert(st.received.Cmp(st.staked) > 0, aidReentrancyDrained, details)
+		if !bankOK || !attackerOK {
+			log.Printf("[reentrancy] INVARIANT BROKEN on %s at %d: %s", name, finalizedHeight, st)
+		}
+		results[st.String()] = append(results[st.String()], name)
+	}
+	if len(results) == 0 {
+		return
+	}
+
+	consistent := len(results) == 1
+	alwaysAssert(consistent, aidReentrancyStateAgree, withDivergence(results, map[string]any{
+		"bank":         bank.addr.String(),
+		"attacker":     attacker.addr.String(),
+		"finalized_at": finalizedHeight,
+		"results":      results,
+	}))
+	if !consistent {
+		log.Printf("[reentrancy] DIVERGENCE for %s/%s at %d: %v", bank.addr, attacker.addr, finalizedHeight, results)
+	}
+}
+
+// readReentrancyState reads balances and ledger slots through the eth API,
+// so balances and storage come from the same post-execution state.
+func readReentrancyState(node api.FullNode, bank, attacker address.Address, blk ethtypes.EthBlockNumberOrHash) (reentrancyState, error) {
+	var st reentrancyState
+	var err error
+	if st.bankBalance, err = ethBalanceAt(node, bank, blk); err != nil {
+		return st, err
+	}
+	if st.attackerBalance, err = ethBalanceAt(node, attacker, blk); err != nil {
+		return st, err
+	}
+	for _, r := range []struct {
+		contract address.Address
+		slot     uint64
+		dst      **big.Int
+	}{
+		{bank, 0, &st.bankTotal},
+		{attacker, 2, &st.received},
+		{attacker, 3, &st.staked},
+	} {
+		val, err := getStorageAt(node, r.contract, encodeUint256(r.slot), blk)
+		if err != nil {
+			return st, err
+		}
+		*r.dst = new(big.Int).SetBytes(val)
+	}
+	return st, nil
+}
+
+// ethBalanceAt reads an actor's balance at blk via EthGetBalance.
+func ethBalanceAt(node api.FullNode, addr address.Address, blk ethtypes.EthBlockNumberOrHash) (*big.Int, error) {
+	ethAddr, err := ethtypes.EthAddressFromFilecoinAddress(addr)
+	if err != nil {
+		return nil, err
+	}
+	bal, err := node.EthGetBalance(ctx, ethAddr, blk)
+	if err != nil {
+		return nil, err
+	}
+	if bal.Int == nil {
+		return new(big.Int), nil
+	}
+	return new(big.Int).Set(bal.Int), nil
+}
@@ -1,16 +1,29 @@
 package main
 
 import (
+	"bytes"
+	"errors"
+	"fmt"
 	"log"
 	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/antithesishq/antithesis-sdk-go/assert"
+	"github.com/antithesishq/antithesis-sdk-go/lifecycle"
 
 	"github.com/filecoin-project/go-address"
 	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/builtin"
 	"github.com/filecoin-project/go-state-types/crypto"
 	"github.com/filecoin-project/lotus/api"
 	"github.com/filecoin-project/lotus/chain/types"
+	"github.com/filecoin-project/lotus/chain/types/ethtypes"
 	"github.com/filecoin-project/lotus/lib/sigs"
 	"github.com/ipfs/go-cid"
+	cbg "github.com/whyrusleeping/cbor-gen"
 )
 
 // debugLogging gates verbose per-action logs. Set STRESS_DEBUG=1 to enable.
@@ -27,16 +40,31 @@ func debugLog(format string, args ...any) {
 // Shared message helpers
 // ===========================================================================
 
+// Default gas parameters for baseMsg, read once at startup. Raising them via
+// STRESS_GAS_LIMIT, STRESS_GAS_FEECAP and STRESS_GAS_PREMIUM fills blocks
+// faster and drives the mempool into base-fee-spike regimes.
+var (
+	defaultGasLimit   = int64(envInt("STRESS_GAS_LIMIT", 1_000_000))
+	defaultGasFeeCap  = abi.NewTokenAmount(int64(envInt("STRESS_GAS_FEECAP", 100_000)))
+	defaultGasPremium = abi.NewTokenAmount(int64(envInt("STRESS_GAS_PREMIUM", 1_000)))
+)
+
 // baseMsg creates a skeleton Filecoin message with conservative gas params.
 func baseMsg(from, to address.Address, value abi.TokenAmount) *types.Message {
+	return baseMsgWithGas(from, to, value, defaultGasLimit, defaultGasFeeCap, defaultGasPremium)
+}
+
+// baseMsgWithGas is baseMsg with explicit gas parameters, for vectors that
+// experiment with fees.
+func baseMsgWithGas(from, to address.Address, value abi.TokenAmount, gasLimit int64, feeCap, premium abi.TokenAmount) *types.Message {
 	return &types.Message{
 		From:       from,
 		To:         to,
 		Value:      value,
 		Method:     0, // plain transfer
-		GasLimit:   1_000_000,
-		GasFeeCap:  abi.NewTokenAmount(100_000),
-		GasPremium: abi.NewTokenAmount(1_000),
+		GasLimit:   gasLimit,
+		GasFeeCap:  feeCap,
+		GasPremium: premium,
 	}
 }
 
@@ -45,7 +73,11 @@ func baseMsg(from, to address.Address, value abi.TokenAmount) *types.Message {
 func signMsg(msg *types.Message, ki *types.KeyInfo) *types.SignedMessage {
 	msgBytes := msg.Cid().Bytes()
 
-	sig, err := sigs.Sign(crypto.SigTypeSecp256k1, ki.PrivateKey, msgBytes)
+	sigType := crypto.SigTypeSecp256k1
+	if ki.Type == types.KTBLS {
+		sigType = crypto.SigTypeBLS
+	}
+	sig, err := sigs.Sign(sigType, ki.PrivateKey, msgBytes)
 	if err != nil {
 		log.Printf("[sign] signing failed for %s: %v", msg.From, err)
 		return nil
@@ -56,6 +88,61 @@ func signMsg(msg *types.Message, ki *types.KeyInfo) *types.SignedMessage {
 	}
 }
 
+// dryRun makes every message submission log what it would send and skip the
+// RPC, so monitoring vectors can be validated against a live cluster without
+// mutating it. Set STRESS_DRY_RUN=1 to enable.
+var dryRun = os.Getenv("STRESS_DRY_RUN") == "1"
+
+// deckCheck builds and validates the deck, logs its weight distribution and
+// exits before connecting to any node. Set STRESS_DRY_RUN=deck to enable.
+var deckCheck = os.Getenv("STRESS_DRY_RUN") == "deck"
+
+// sleepCtx sleeps for d or until shutdown cancels ctx, and reports whether
+// the full duration elapsed.
+func sleepCtx(d time.Duration) bool {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// errDryRun is returned by submissions skipped under STRESS_DRY_RUN.
+var errDryRun = errors.New("dry run: submission skipped")
+
+// mpoolPush is the single choke point for MpoolPush; every vector submits
+// through it so STRESS_DRY_RUN covers all mutating paths.
+func mpoolPush(node api.FullNode, smsg *types.SignedMessage) (cid.Cid, error) {
+	if dryRun {
+		m := smsg.Message
+		log.Printf("[dry-run] would push %s -> %s nonce=%d method=%d value=%s sig=%d",
+			m.From, m.To, m.Nonce, m.Method, m.Value, smsg.Signature.Type)
+		return cid.Undef, errDryRun
+	}
+	return node.MpoolPush(ctx, smsg)
+}
+
+// sendEthTx is the eth_sendRawTransaction counterpart of mpoolPush.
+func sendEthTx(node api.FullNode, raw []byte) (ethtypes.EthHash, error) {
+	if dryRun {
+		log.Printf("[dry-run] would send raw eth tx (%d bytes)", len(raw))
+		return ethtypes.EmptyEthHash, errDryRun
+	}
+	return node.EthSendRawTransaction(ctx, raw)
+}
+
+// mpoolBatchPush is the MpoolBatchPush counterpart of mpoolPush.
+func mpoolBatchPush(node api.FullNode, smsgs []*types.SignedMessage) ([]cid.Cid, error) {
+	if dryRun {
+		log.Printf("[dry-run] would batch-push %d messages", len(smsgs))
+		return nil, errDryRun
+	}
+	return node.MpoolBatchPush(ctx, smsgs)
+}
+
 // pushMsg signs locally and pushes a single message to the mempool.
 // Manages nonces: increments only on success.
 func pushMsg(node api.FullNode, msg *types.Message, ki *types.KeyInfo, tag string) bool {
@@ -66,16 +153,375 @@ func pushMsg(node api.FullNode, msg *types.Message, ki *types.KeyInfo, tag strin
 		return false
 	}
 
-	_, err := node.MpoolPush(ctx, smsg)
+	msgCid, err := mpoolPush(node, smsg)
 	if err != nil {
 		log.Printf("[%s] MpoolPush failed: %v", tag, err)
 		return false
 	}
 
 	nonces[msg.From]++
+	trackMsgGas(msgCid)
 	return true
 }
 
+// pushBatchParallelism bounds in-flight MpoolPush calls when a node rejects
+// MpoolBatchPush and pushMsgBatch falls back to individual pushes.
+const pushBatchParallelism = 8
+
+// Batch submission stats for the effective push rate (protected by batchStatsMu)
+var (
+	batchStatsMu     sync.Mutex
+	batchPushed      int
+	batchPushElapsed time.Duration
+)
+
+// pushMsgBatch signs many messages locally and submits them in a single
+// MpoolBatchPush round-trip. If the node rejects part of the batch, the
+// messages after the accepted prefix fall back to concurrent MpoolPush calls
+// bounded by pushBatchParallelism. Nonces are
+// assigned in order per sender; if anything was rejected, the affected
+// senders are resynced from the node. Returns the number of accepted messages.
+func pushMsgBatch(node api.FullNode, msgs []*types.Message, kis []*types.KeyInfo, tag string) int {
+	start := time.Now()
+
+	smsgs := make([]*types.SignedMessage, 0, len(msgs))
+	for i, msg := range msgs {
+		msg.Nonce = nonces[msg.From]
+		smsg := signMsg(msg, kis[i])
+		if smsg == nil {
+			continue
+		}
+		nonces[msg.From]++
+		smsgs = append(smsgs, smsg)
+	}
+	if len(smsgs) == 0 {
+		return 0
+	}
+
+	// MpoolBatchPush pushes in order and stops at the first rejection, but
+	// over JSON-RPC the error arrives without the accepted CIDs. The node's
+	// pending nonce for each sender tells which messages it already holds;
+	// only the rest go through the fallback.
+	cids, err := mpoolBatchPush(node, smsgs)
+	accepted := len(cids)
+	for _, c := range cids {
+		trackMsgGas(c)
+	}
+	if err != nil && !errors.Is(err, errDryRun) {
+		held, rest := splitAccepted(node, smsgs)
+		for _, smsg := range held {
+			trackMsgGas(smsg.Cid())
+		}
+		accepted = len(held)
+		debugLog("[%s] MpoolBatchPush failed after %d/%d: %v, falling back to concurrent pushes for %d",
+			tag, len(held), len(smsgs), err, len(rest))
+		accepted += pushConcurrent(node, rest, tag)
+	}
+
+	if accepted < len(smsgs) {
+		resyncNonces(node, smsgs)
+	}
+
+	batchStatsMu.Lock()
+	batchPushed += accepted
+	batchPushElapsed += time.Since(start)
+	batchStatsMu.Unlock()
+
+	return accepted
+}
+
+// pushConcurrent pushes pre-signed messages with bounded parallelism and
+// returns how many the node accepted.
+func pushConcurrent(node api.FullNode, smsgs []*types.SignedMessage, tag string) int {
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		accepted int
+	)
+	sem := make(chan struct{}, pushBatchParallelism)
+	for _, smsg := range smsgs {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(sm *types.SignedMessage) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			msgCid, err := mpoolPush(node, sm)
+			if err != nil {
+				debugLog("[%s] MpoolPush failed for nonce %d: %v", tag, sm.Message.Nonce, err)
+				return
+			}
+			trackMsgGas(msgCid)
+			mu.Lock()
+			accepted++
+			mu.Unlock()
+		}(smsg)
+	}
+	wg.Wait()
+	return accepted
+}
+
+// splitAccepted partitions smsgs into the messages node already holds and
+// the ones it does not, judged by each sender's pending nonce on node. A
+// sender whose nonce cannot be read is treated as holding nothing.
+func splitAccepted(node api.FullNode, smsgs []*types.SignedMessage) (held, rest []*types.SignedMessage) {
+	next := make(map[address.Address]uint64)
+	for _, smsg := range smsgs {
+		from := smsg.Message.From
+		n, ok := next[from]
+		if !ok {
+			var err error
+			if n, err = node.MpoolGetNonce(ctx, from); err != nil {
+				log.Printf("[batch-push] MpoolGetNonce failed for %s: %v", from, err)
+				n = 0
+			}
+			next[from] = n
+		}
+		if smsg.Message.Nonce < n {
+			held = append(held, smsg)
+		} else {
+			rest = append(rest, smsg)
+		}
+	}
+	return held, rest
+}
+
+// resyncNonces resets the local nonce of every sender in smsgs to the node's view.
+func resyncNonces(node api.FullNode, smsgs []*types.SignedMessage) {
+	seen := make(map[address.Address]bool)
+	for _, smsg := range smsgs {
+		from := smsg.Message.From
+		if seen[from] {
+			continue
+		}
+		seen[from] = true
+		n, err := node.MpoolGetNonce(ctx, from)
+		if err != nil {
+			log.Printf("[batch-push] MpoolGetNonce failed for %s: %v", from, err)
+			continue
+		}
+		nonces[from] = n
+	}
+}
+
+// batchPushRate returns the effective batched submission rate in msgs/sec.
+func batchPushRate() (int, float64) {
+	batchStatsMu.Lock()
+	defer batchStatsMu.Unlock()
+	if batchPushElapsed <= 0 {
+		return batchPushed, 0
+	}
+	return batchPushed, float64(batchPushed) / batchPushElapsed.Seconds()
+}
+
+// ethChainID is the EIP-155 chain id the devnet nodes are built with (2k build).
+var ethChainID = envInt("STRESS_ETH_CHAIN_ID", 31415926)
+
+// deriveFilAddr derives the f410 delegated address and Ethereum address
+// controlled by a secp256k1 private key.
+func deriveFilAddr(pk []byte) (address.Address, ethtypes.EthAddress, error) {
+	pub, err := sigs.ToPublic(crypto.SigTypeDelegated, pk)
+	if err != nil {
+		return address.Undef, ethtypes.EthAddress{}, err
+	}
+	ethBytes, err := ethtypes.EthAddressFromPubKey(pub)
+	if err != nil {
+		return address.Undef, ethtypes.EthAddress{}, err
+	}
+	ethAddr, err := ethtypes.CastEthAddress(ethBytes)
+	if err != nil {
+		return address.Undef, ethtypes.EthAddress{}, err
+	}
+	filAddr, err := ethAddr.ToFilecoinAddress()
+	if err != nil {
+		return address.Undef, ethtypes.EthAddress{}, err
+	}
+	return filAddr, ethAddr, nil
+}
+
+// signDelegatedMsg signs a native message from an f4 sender with a delegated
+// signature. The node authenticates delegated signatures by rebuilding the
+// equivalent EIP-1559 transaction, so the message must be an InvokeContract
+// whose params are a CBOR byte array (or empty).
+func signDelegatedMsg(msg *types.Message, pk []byte) (*types.SignedMessage, error) {
+	tx, err := delegatedTxArgs(msg)
+	if err != nil {
+		return nil, err
+	}
+	payload, err := tx.ToRlpUnsignedMsg()
+	if err != nil {
+		return nil, err
+	}
+	sig, err := sigs.Sign(crypto.SigTypeDelegated, pk, payload)
+	if err != nil {
+		return nil, err
+	}
+	return &types.SignedMessage{
+		Message:   *msg,
+		Signature: *sig,
+	}, nil
+}
+
+// delegatedTxHash computes the eth transaction hash of a delegated-signed
+// message, using the devnet chain id rather than the build's.
+func delegatedTxHash(smsg *types.SignedMessage) (ethtypes.EthHash, error) {
+	tx, err := delegatedSignedTx(smsg)
+	if err != nil {
+		return ethtypes.EmptyEthHash, err
+	}
+	return tx.TxHash()
+}
+
+// delegatedRawTx returns the RLP-encoded signed eth transaction for a
+// delegated-signed message, as eth_sendRawTransaction expects it.
+func delegatedRawTx(smsg *types.SignedMessage) ([]byte, error) {
+	tx, err := delegatedSignedTx(smsg)
+	if err != nil {
+		return nil, err
+	}
+	return tx.ToRlpSignedMsg()
+}
+
+// delegatedSignedTx builds the EIP-1559 transaction for smsg, carrying its signature.
+func delegatedSignedTx(smsg *types.SignedMessage) (*ethtypes.Eth1559TxArgs, error) {
+	tx, err := delegatedTxArgs(&smsg.Message)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.InitialiseSignature(smsg.Signature); err != nil {
+		return nil, err
+	}
+	return tx, nil
+}
+
+// delegatedTxArgs builds the EIP-1559 transaction equivalent to msg.
+func delegatedTxArgs(msg *types.Message) (*ethtypes.Eth1559TxArgs, error) {
+	to, err := ethtypes.EthAddressFromFilecoinAddress(msg.To)
+	if err != nil {
+		return nil, err
+	}
+	var input []byte
+	if len(msg.Params) > 0 {
+		input, err = cbg.ReadByteArray(bytes.NewReader(msg.Params), uint64(len(msg.Params)))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &ethtypes.Eth1559TxArgs{
+		ChainID:              ethChainID,
+		Nonce:                int(msg.Nonce),
+		To:                   &to,
+		Value:                msg.Value,
+		MaxFeePerGas:         msg.GasFeeCap,
+		MaxPriorityFeePerGas: msg.GasPremium,
+		GasLimit:             int(msg.GasLimit),
+		Input:                input,
+	}, nil
+}
+
+// ===========================================================================
+// Read RPC retry
+// ===========================================================================
+
+const (
+	rpcRetryAttempts = 3
+	rpcRetryBase     = 250 * time.Millisecond
+)
+
+// transientRPCMarkers identify errors worth retrying: the request may succeed
+// if sent again (dropped connection, overloaded node). Anything else — not
+// found, invalid params, unsupported method — is persistent and returned as-is.
+var transientRPCMarkers = []string{
+	"timeout", "deadline exceeded", "connection", "EOF", "websocket",
+	"broken pipe", "reset by peer", "temporarily unavailable", "503",
+}
+
+func isTransientRPCError(err error) bool {
+	if err == nil || ctx.Err() != nil {
+		return false
+	}
+	msg := err.Error()
+	for _, m := range transientRPCMarkers {
+		if strings.Contains(msg, m) {
+			return true
+		}
+	}
+	return false
+}
+
+// retryRPC runs a read-only RPC, retrying transient failures with jittered
+// backoff so a single blip doesn't abort an expensive consistency check.
+// Once shutdown begins it stops waiting and returns ctx.Err().
+func retryRPC[T any](fn func() (T, error)) (T, error) {
+	v, err := fn()
+	for attempt := 1; attempt < rpcRetryAttempts && isTransientRPCError(err); attempt++ {
+		backoff := rpcRetryBase << (attempt - 1)
+		jitter := time.Duration(rngIntn(int(backoff)))
+		if !sleepCtx(backoff + jitter) {
+			var zero T
+			return zero, ctx.Err()
+		}
+		v, err = fn()
+	}
+	return v, err
+}
+
+// finalizedTipSet is ChainGetFinalizedTipSet with retry.
+func finalizedTipSet(name string) (*types.TipSet, error) {
+	return retryRPC(func() (*types.TipSet, error) {
+		return nodes[name].ChainGetFinalizedTipSet(ctx)
+	})
+}
+
+// receiptLookback is how many epochs after submission a queued message's
+// receipt is waited for before the entry is dropped as never executed.
+const receiptLookback = 100
+
+// headEpoch returns the height of node's head, or 0 if it cannot be read.
+func headEpoch(node api.FullNode) abi.ChainEpoch {
+	head, err := node.ChainHead(ctx)
+	if err != nil {
+		return 0
+	}
+	return head.Height()
+}
+
+// getEthReceipt resolves a Filecoin message to its Eth tx hash and returns
+// the Eth-style receipt. Returns (nil, nil) if the message is not yet
+// executed.
+func getEthReceipt(node api.FullNode, msgCid cid.Cid) (*api.EthTxReceipt, error) {
+	hash, err := node.EthGetTransactionHashByCid(ctx, msgCid)
+	if err != nil {
+		return nil, err
+	}
+	if hash == nil {
+		return nil, nil
+	}
+	return node.EthGetTransactionReceipt(ctx, *hash)
+}
+
+// getStorageAt reads one 32-byte storage slot of an EVM contract at blk via
+// EthGetStorageAt.
+func getStorageAt(node api.FullNode, contract address.Address, slot []byte, blk ethtypes.EthBlockNumberOrHash) ([]byte, error) {
+	ethAddr, err := ethtypes.EthAddressFromFilecoinAddress(contract)
+	if err != nil {
+		return nil, err
+	}
+	val, err := node.EthGetStorageAt(ctx, ethAddr, slot, blk)
+	if err != nil || len(val) >= 32 {
+		return val, err
+	}
+	// Left-pad short encodings so values compare as 32-byte words.
+	return append(make([]byte, 32-len(val)), val...), nil
+}
+
+// tipSetByHeight is ChainGetTipSetByHeight with retry.
+func tipSetByHeight(name string, h abi.ChainEpoch, tsk types.TipSetKey) (*types.TipSet, error) {
+	return retryRPC(func() (*types.TipSet, error) {
+		return nodes[name].ChainGetTipSetByHeight(ctx, h, tsk)
+	})
+}
+
 // nodeType returns "lotus" or "forest" based on node name prefix.
 func nodeType(name string) string {
 	if len(name) >= 6 && name[:6] == "forest" {
@@ -84,6 +530,167 @@ func nodeType(name string) string {
 	return "lotus"
 }
 
+// ===========================================================================
+// Assertion Coverage
+//
+// Vectors assert through alwaysAssert / sometimesAssert, drop-in
+// replacements for the SDK calls that first tally each evaluation per
+// assertion ID, so the periodic summary shows which assertions rarely-drawn
+// vectors actually reached.
+// ===========================================================================
+
+// assertionStat counts one assertion's evaluations and passes.
+type assertionStat struct {
+	Evaluated int `json:"evaluated"`
+	Passed    int `json:"passed"`
+}
+
+var (
+	assertStatsMu sync.Mutex
+	assertStats   = make(map[string]assertionStat)
+)
+
+// recordAssertion tallies one evaluation of the assertion and reports
+// whether it should be sent to the SDK. Once shutdown has cancelled ctx,
+// in-flight RPCs fail and conditions are meaningless, so it records nothing
+// and returns false.
+func recordAssertion(message string, condition bool) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	assertStatsMu.Lock()
+	defer assertStatsMu.Unlock()
+	st := assertStats[message]
+	st.Evaluated++
+	if condition {
+		st.Passed++
+	}
+	assertStats[message] = st
+	return true
+}
+
+// alwaysAssert tallies one evaluation and forwards it to assert.Always.
+func alwaysAssert(condition bool, message string, details map[string]any) {
+	if recordAssertion(message, condition) {
+		assert.Always(condition, message, details)
+	}
+}
+
+// sometimesAssert tallies one evaluation and forwards it to assert.Sometimes.
+func sometimesAssert(condition bool, message string, details map[string]any) {
+	if recordAssertion(message, condition) {
+		assert.Sometimes(condition, message, details)
+	}
+}
+
+// snapshotAssertionStats returns the tally of every registered assertion,
+// including ones never evaluated.
+func snapshotAssertionStats() map[string]assertionStat {
+	assertStatsMu.Lock()
+	defer assertStatsMu.Unlock()
+	out := make(map[string]assertionStat, len(assertionIDs))
+	for _, id := range assertionIDs {
+		out[id] = assertStats[id]
+	}
+	return out
+}
+
+// ===========================================================================
+// Divergence Tagging
+//
+// Cross-node Always assertions group nodes by the value they reported. When
+// one fires, withDivergence enriches its details with each node's
+// implementation and a majority/minority split, so the report reads "1
+// forest node disagreed with 2 lotus nodes" instead of a bare map of hashes.
+// ===========================================================================
+
+// nodeVersions holds each node's Version() string, probed once at startup.
+var nodeVersions = make(map[string]string)
+
+// probeNodeVersions records every node's implementation version.
+func probeNodeVersions() {
+	for _, name := range nodeKeys {
+		v, err := nodes[name].Version(ctx)
+		if err != nil {
+			log.Printf("[init] Version probe failed for %s: %v", name, err)
+			continue
+		}
+		nodeVersions[name] = v.Version
+		log.Printf("[init] %s runs %s %s", name, nodeType(name), v.Version)
+	}
+}
+
+// nodeImpl returns "<type> <version>" for a node, or just the type if the
+// version probe failed.
+func nodeImpl(name string) string {
+	if v := nodeVersions[name]; v != "" {
+		return nodeType(name) + " " + v
+	}
+	return nodeType(name)
+}
+
+// withDivergence adds majority/minority grouping and implementations to
+// details, given groups of node names keyed by reported value. The largest
+// group is the majority; ties break on key order for stable reports.
+func withDivergence(groups map[string][]string, details map[string]any) map[string]any {
+	keys := make([]string, 0, len(groups))
+	for k := range groups {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	majorityKey := ""
+	for _, k := range keys {
+		if majorityKey == "" || len(groups[k]) > len(groups[majorityKey]) {
+			majorityKey = k
+		}
+	}
+
+	impls := make(map[string]string)
+	var majority, minority []string
+	for _, k := range keys {
+		for _, name := range groups[k] {
+			impls[name] = nodeImpl(name)
+			if k == majorityKey {
+				majority = append(majority, name)
+			} else {
+				minority = append(minority, name)
+			}
+		}
+	}
+
+	details["implementations"] = impls
+	details["majority"] = majority
+	details["minority"] = minority
+	if len(minority) > 0 {
+		details["divergence"] = fmt.Sprintf("%s disagreed with %s", describeNodes(minority), describeNodes(majority))
+	}
+	return details
+}
+
+// describeNodes summarizes node names by type, e.g. "1 forest node, 2 lotus nodes".
+func describeNodes(names []string) string {
+	counts := make(map[string]int)
+	for _, name := range names {
+		counts[nodeType(name)]++
+	}
+	kinds := make([]string, 0, len(counts))
+	for t := range counts {
+		kinds = append(kinds, t)
+	}
+	sort.Strings(kinds)
+
+	parts := make([]string, 0, len(kinds))
+	for _, t := range kinds {
+		noun := "node"
+		if counts[t] != 1 {
+			noun = "nodes"
+		}
+		parts = append(parts, fmt.Sprintf("%d %s %s", counts[t], t, noun))
+	}
+	return strings.Join(parts, ", ")
+}
+
 // errStr safely converts an error to string for assertion details.
 func errStr(err error) string {
 	if err == nil {
@@ -113,3 +720,152 @@ func getContractsByType(ctype string) []deployedContract {
 	}
 	return result
 }
+
+// recordContractDeploy counts a confirmed deployment of the given contract type.
+func recordContractDeploy(ctype string) {
+	contractsMu.Lock()
+	contractDeployCounts[ctype]++
+	contractsMu.Unlock()
+}
+
+// recordContractCall counts a successfully submitted call to the given contract type.
+func recordContractCall(ctype string) {
+	contractsMu.Lock()
+	contractCallCounts[ctype]++
+	contractsMu.Unlock()
+}
+
+// ===========================================================================
+// Phase markers
+// ===========================================================================
+
+// phaseSeq numbers phase markers so a start can be paired with its end.
+var phaseSeq int
+
+// beginPhase emits a lifecycle "engine_phase" start marker and returns a
+// function that emits the matching end marker. Antithesis uses these to
+// correlate injected faults with what the engine was doing at the time:
+//
+//	defer beginPhase("reorg-chaos", map[string]any{"victim": victimName})()
+func beginPhase(phase string, details map[string]any) func() {
+	phaseSeq++
+	seq := phaseSeq
+	start := time.Now()
+
+	event := map[string]any{"phase": phase, "stage": "start", "seq": seq}
+	for k, v := range details {
+		event[k] = v
+	}
+	lifecycle.SendEvent("engine_phase", event)
+	debugLog("  [phase] start %s #%d", phase, seq)
+
+	return func() {
+		lifecycle.SendEvent("engine_phase", map[string]any{
+			"phase":      phase,
+			"stage":      "end",
+			"seq":        seq,
+			"elapsed_ms": time.Since(start).Milliseconds(),
+		})
+		debugLog("  [phase] end %s #%d", phase, seq)
+	}
+}
+
+// ===========================================================================
+// Capability Gating
+//
+// Forest and Lotus do not implement the same set of optional APIs. Each
+// node is probed once at startup; vectors that need an optional method pick
+// only nodes that support it, so a mixed cluster doesn't produce a stream of
+// "method not found" failures that drown out real ones.
+// ===========================================================================
+
+// capabilityProbes make one cheap call per optional API.
+var capabilityProbes = map[string]func(node api.FullNode, head *types.TipSet) error{
+	"StateCompute": func(node api.FullNode, head *types.TipSet) error {
+		_, err := node.StateCompute(ctx, head.Height(), nil, head.Key())
+		return err
+	},
+	"ChainStatObj": func(node api.FullNode, head *types.TipSet) error {
+		_, err := node.ChainStatObj(ctx, head.Blocks()[0].Messages, cid.Undef)
+		return err
+	},
+	"ChainTipSetWeight": func(node api.FullNode, head *types.TipSet) error {
+		_, err := node.ChainTipSetWeight(ctx, head.Key())
+		return err
+	},
+	"StateMarketBalance": func(node api.FullNode, head *types.TipSet) error {
+		_, err := node.StateMarketBalance(ctx, builtin.SystemActorAddr, head.Key())
+		return err
+	},
+	"StateReplay": func(node api.FullNode, head *types.TipSet) error {
+		// Replaying a non-message CID fails, but not as an unknown method.
+		_, err := node.StateReplay(ctx, types.EmptyTSK, head.Blocks()[0].Messages)
+		return err
+	},
+}
+
+// nodeCaps records, per node, whether each probed method is supported.
+var nodeCaps = make(map[string]map[string]bool)
+
+// probeNodeCapabilities runs every capability probe against every node.
+// Only an "unknown method" style error disables a method; other failures
+// are treated as transient and leave it enabled.
+func probeNodeCapabilities() {
+	for _, name := range nodeKeys {
+		head, err := nodes[name].ChainHead(ctx)
+		if err != nil {
+			log.Printf("[init] capability probe skipped for %s: %v", name, err)
+			continue
+		}
+		caps := make(map[string]bool, len(capabilityProbes))
+		var missing []string
+		for method, probe := range capabilityProbes {
+			err := probe(nodes[name], head)
+			caps[method] = err == nil || !isUnsupportedMethod(err)
+			if !caps[method] {
+				missing = append(missing, method)
+			}
+		}
+		nodeCaps[name] = caps
+		if len(missing) > 0 {
+			sort.Strings(missing)
+			log.Printf("[init] %s does not support %v; vectors needing them skip it", name, missing)
+		}
+	}
+}
+
+// isUnsupportedMethod reports whether err means the node lacks the method.
+func isUnsupportedMethod(err error) bool {
+	msg := strings.ToLower(err.Error())
+	return strings.Contains(msg, "method not found") ||
+		(strings.Contains(msg, "method '") && strings.Contains(msg, "not found")) ||
+		strings.Contains(msg, "not implemented") ||
+		strings.Contains(msg, "unsupported method")
+}
+
+// nodeSupports reports whether a node implements method. Nodes or methods
+// that were never probed are assumed supported.
+func nodeSupports(name, method string) bool {
+	caps, ok := nodeCaps[name]
+	if !ok {
+		return true
+	}
+	supported, ok := caps[method]
+	return !ok || supported
+}
+
+// pickNodeWith is pickNode restricted to nodes that support method. ok is
+// false if no node does.
+func pickNodeWith(method string) (string, api.FullNode, bool) {
+	var capable []string
+	for _, name := range nodeKeys {
+		if nodeSupports(name, method) {
+			capable = append(capable, name)
+		}
+	}
+	if len(capable) == 0 {
+		return "", nil, false
+	}
+	name := rngChoice(capable)
+	return name, nodes[name], true
+}
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"testing"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/crypto"
+	"github.com/filecoin-project/lotus/api"
+	"github.com/filecoin-project/lotus/chain/types"
+	"github.com/filecoin-project/lotus/lib/sigs"
+
+	"workload/internal/chain/chaintest"
+)
+
+// useMockNodes points the engine globals at the given mock nodes for the
+// duration of the test.
+func useMockNodes(t *testing.T, mocks map[string]*chaintest.MockNode) {
+	t.Helper()
+	prevCtx, prevNodes, prevKeys := ctx, nodes, nodeKeys
+	t.Cleanup(func() { ctx, nodes, nodeKeys = prevCtx, prevNodes, prevKeys })
+
+	ctx = context.Background()
+	nodes = make(map[string]api.FullNode, len(mocks))
+	nodeKeys = nodeKeys[:0:0]
+	for name, m := range mocks {
+		nodes[name] = m
+		nodeKeys = append(nodeKeys, name)
+	}
+	sort.Strings(nodeKeys)
+}
+
+// useMockWallets installs n fresh secp256k1 wallets with zero local nonces
+// for the duration of the test.
+func useMockWallets(t *testing.T, n int) []address.Address {
+	t.Helper()
+	prevKeystore, prevAddrs, prevNonces := keystore, addrs, nonces
+	t.Cleanup(func() { keystore, addrs, nonces = prevKeystore, prevAddrs, prevNonces })
+
+	keystore = make(map[address.Address]*types.KeyInfo, n)
+	addrs = nil
+	nonces = make(map[address.Address]uint64, n)
+	for i := 0; i < n; i++ {
+		pk, err := sigs.Generate(crypto.SigTypeSecp256k1)
+		if err != nil {
+			t.Fatalf("generate key: %v", err)
+		}
+		pub, err := sigs.ToPublic(crypto.SigTypeSecp256k1, pk)
+		if err != nil {
+			t.Fatalf("derive public key: %v", err)
+		}
+		addr, err := address.NewSecp256k1Address(pub)
+		if err != nil {
+			t.Fatalf("derive address: %v", err)
+		}
+		keystore[addr] = &types.KeyInfo{Type: types.KTSecp256k1, PrivateKey: pk}
+		addrs = append(addrs, addr)
+	}
+	return addrs
+}
+
+// mockChain builds a linear chain of single-block tipsets at heights
+// 1..head whose parent state roots are derived from rootTag, so chains built
+// with different tags diverge at every height.
+func mockChain(head abi.ChainEpoch, rootTag string) []*types.TipSet {
+	var (
+		chain   []*types.TipSet
+		parents types.TipSetKey
+	)
+	for h := abi.ChainEpoch(1); h <= head; h++ {
+		ts := chaintest.MockTipSet(h, parents, chaintest.MockCid(fmt.Sprintf("%s-%d", rootTag, h)), 1000)
+		chain = append(chain, ts)
+		parents = ts.Key()
+	}
+	return chain
+}
+
+// assertionTally returns the recorded evaluations of one assertion.
+func assertionTally(id string) assertionStat {
+	assertStatsMu.Lock()
+	defer assertStatsMu.Unlock()
+	return assertStats[id]
+}
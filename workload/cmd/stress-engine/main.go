@@ -6,12 +6,17 @@ import (
 	"encoding/json"
 	"log"
 	"os"
+	"os/signal"
+	"path/filepath"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
+	"syscall"
 	"time"
 
 	"workload/internal/chain"
+	"workload/internal/genesis"
 
 	"github.com/antithesishq/antithesis-sdk-go/lifecycle"
 	"github.com/antithesishq/antithesis-sdk-go/random"
@@ -22,6 +27,9 @@ import (
 	"github.com/filecoin-project/lotus/chain/types"
 	_ "github.com/filecoin-project/lotus/lib/sigs/secp"
 	"github.com/ipfs/go-cid"
+
+	// Pure-Go BLS signer; lotus/lib/sigs/bls needs filecoin-ffi
+	_ "workload/internal/bls"
 )
 
 // ---------------------------------------------------------------------------
@@ -36,6 +44,12 @@ var (
 	nodes    map[string]api.FullNode
 	nodeKeys []string
 
+	// Connection config, kept for vectors that talk raw HTTP to nodes
+	nodeConfig chain.NodeConfig
+
+	// Closes every node connection; deferred by main for a clean shutdown
+	closeNodes func()
+
 	// Wallet state loaded from stress_keystore.json
 	keystore map[address.Address]*types.KeyInfo
 	addrs    []address.Address
@@ -50,28 +64,46 @@ var (
 	deployedContracts []deployedContract
 	contractsMu       sync.Mutex
 
+	// Per-contract-type coverage counters (protected by contractsMu)
+	contractDeployCounts = make(map[string]int)
+	contractCallCounts   = make(map[string]int)
+
 	// Contract bytecodes (loaded from embedded hex in contracts.go)
 	contractBytecodes map[string][]byte
 	contractTypes     []string // keys of contractBytecodes for random selection
 
-	// Pending deploy CIDs for deferred verification
+	// Pending deploy and call CIDs for deferred verification (protected by pendingMu)
 	pendingDeploys []pendingDeploy
+	pendingCalls   []pendingCall
 	pendingMu      sync.Mutex
 )
 
 type deployedContract struct {
-	addr     address.Address
-	ctype    string // "recursive", "selfdestruct", "simplecoin", etc.
-	deployer address.Address
-	deployKI *types.KeyInfo
+	addr      address.Address
+	ctype     string // "recursive", "selfdestruct", "simplecoin", etc.
+	deployer  address.Address
+	deployKI  *types.KeyInfo
+	viaCreate bool // deployed via factory-relayed EAM.Create rather than CreateExternal
 }
 
 type pendingDeploy struct {
-	msgCid   cid.Cid
-	ctype    string
-	deployer address.Address
-	deployKI *types.KeyInfo
-	epoch    abi.ChainEpoch
+	msgCid      cid.Cid
+	ctype       string
+	deployer    address.Address
+	deployKI    *types.KeyInfo
+	epoch       abi.ChainEpoch
+	viaCreate   bool            // EAM.Create relayed through factory with createNonce
+	factory     address.Address // createfactory that called EAM.Create
+	createNonce uint64          // address-derivation nonce passed to EAM.Create
+	redeploy    bool            // repeat of a confirmed Create; must fail
+}
+
+type pendingCall struct {
+	msgCid cid.Cid
+	tag    string // sub-action, e.g. "recursive-call"
+	depth  uint64
+	expect callOutcome
+	epoch  abi.ChainEpoch // head height at submit
 }
 
 // namedAction pairs an action function with its name for logging
@@ -134,23 +166,34 @@ func pickWallet() (address.Address, *types.KeyInfo) {
 // ---------------------------------------------------------------------------
 
 func connectNodes() {
-	cfg := chain.NodeConfig{
-		Names:      strings.Split(envOrDefault("STRESS_NODES", "lotus0"), ","),
+	nodeConfig = chain.NodeConfig{
+		Names:      configuredNodeNames(),
 		Port:       envOrDefault("STRESS_RPC_PORT", "1234"),
 		ForestPort: envOrDefault("STRESS_FOREST_RPC_PORT", "3456"),
+		Transport:  envOrDefault("STRESS_RPC_TRANSPORT", "ws"),
+
+		MaxAttempts: envInt("STRESS_CONNECT_ATTEMPTS", chain.DefaultMaxAttempts),
+		RetryDelay:  time.Duration(envInt("STRESS_CONNECT_RETRY_MS", 1000)) * time.Millisecond,
 	}
 
 	var err error
-	nodes, nodeKeys, err = chain.ConnectNodes(ctx, cfg)
+	nodes, nodeKeys, closeNodes, err = chain.ConnectNodes(ctx, nodeConfig)
 	if err != nil {
 		log.Fatalf("[init] FATAL: %v", err)
 	}
 }
 
+// configuredNodeNames returns the node names from STRESS_NODES.
+func configuredNodeNames() []string {
+	return strings.Split(envOrDefault("STRESS_NODES", "lotus0"), ",")
+}
+
 // KeystoreEntry matches the JSON format written by genesis-prep.
 type KeystoreEntry struct {
 	Address    string `json:"Address"`
 	PrivateKey string `json:"PrivateKey"`
+	Type       string `json:"Type,omitempty"`    // empty means secp256k1
+	Balance    string `json:"Balance,omitempty"` // genesis attoFIL, when recorded
 }
 
 func loadKeystore() {
@@ -164,6 +207,7 @@ func loadKeystore() {
 	if err := json.Unmarshal(data, &entries); err != nil {
 		log.Fatalf("[init] FATAL: cannot parse keystore: %v", err)
 	}
+	verifyGenesisManifest(path, entries)
 
 	keystore = make(map[address.Address]*types.KeyInfo, len(entries))
 	nonces = make(map[address.Address]uint64, len(entries))
@@ -180,8 +224,12 @@ func loadKeystore() {
 			log.Printf("[init] WARN: skipping address %s, bad private key hex: %v", e.Address, err)
 			continue
 		}
+		kt := types.KTSecp256k1
+		if e.Type == string(types.KTBLS) {
+			kt = types.KTBLS
+		}
 		keystore[addr] = &types.KeyInfo{
-			Type:       types.KTSecp256k1,
+			Type:       kt,
 			PrivateKey: pk,
 		}
 		addrs = append(addrs, addr)
@@ -193,6 +241,37 @@ func loadKeystore() {
 	log.Printf("[init] loaded %d keys from keystore", len(addrs))
 }
 
+// verifyGenesisManifest fails fast if the keystore is not the wallet set the
+// genesis was built from, e.g. because genesis-prep ran with another seed.
+// The manifest sits next to the keystore unless STRESS_GENESIS_MANIFEST_PATH
+// says otherwise; keystores from before the manifest existed are accepted.
+func verifyGenesisManifest(keystorePath string, entries []KeystoreEntry) {
+	path := envOrDefault("STRESS_GENESIS_MANIFEST_PATH", filepath.Join(filepath.Dir(keystorePath), genesis.ManifestFile))
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		log.Printf("[init] WARN: no genesis manifest at %s, keystore not verified", path)
+		return
+	}
+	if err != nil {
+		log.Fatalf("[init] FATAL: cannot read genesis manifest at %s: %v", path, err)
+	}
+
+	var m genesis.Manifest
+	if err := json.Unmarshal(data, &m); err != nil {
+		log.Fatalf("[init] FATAL: cannot parse genesis manifest: %v", err)
+	}
+	loaded := make([]string, len(entries))
+	for i, e := range entries {
+		loaded[i] = e.Address
+	}
+	if got := genesis.AddressChecksum(loaded); got != m.AddressesSHA256 || len(entries) != m.Count {
+		log.Fatalf("[init] FATAL: keystore %s (%d wallets, sha256=%s) does not match genesis manifest %s (%d wallets, sha256=%s, seed=%q)",
+			keystorePath, len(entries), got, path, m.Count, m.AddressesSHA256, m.Seed)
+	}
+	log.Printf("[init] keystore matches genesis manifest (seed=%q, %d wallets, distribution=%s, key-type=%s)",
+		m.Seed, m.Count, m.BalanceDistribution, m.KeyType)
+}
+
 func waitForChain() {
 	targetHeight := envInt("STRESS_WAIT_HEIGHT", 10)
 	node := nodes[nodeKeys[0]]
@@ -202,7 +281,9 @@ func waitForChain() {
 		head, err := node.ChainHead(ctx)
 		if err != nil {
 			log.Printf("[init] ChainHead error: %v, retrying...", err)
-			time.Sleep(2 * time.Second)
+			if !sleepCtx(2 * time.Second) {
+				return
+			}
 			continue
 		}
 		if int(head.Height()) >= targetHeight {
@@ -210,7 +291,9 @@ func waitForChain() {
 			return
 		}
 		log.Printf("[init] chain at height %d, waiting...", head.Height())
-		time.Sleep(2 * time.Second)
+		if !sleepCtx(2 * time.Second) {
+			return
+		}
 	}
 }
 
@@ -244,29 +327,92 @@ func buildDeck() {
 		{"DoTransferMarket", "STRESS_WEIGHT_TRANSFER", DoTransferMarket, 0},
 		{"DoGasWar", "STRESS_WEIGHT_GAS_WAR", DoGasWar, 0},
 		{"DoHeavyCompute", "STRESS_WEIGHT_HEAVY_COMPUTE", DoHeavyCompute, 0},
+		{"DoActorReadStorm", "STRESS_WEIGHT_READ_STORM", DoActorReadStorm, 0},
 		{"DoAdversarial", "STRESS_WEIGHT_ADVERSARIAL", DoAdversarial, 0},
+		{"DoMpoolLeakCheck", "STRESS_WEIGHT_MPOOL_LEAK", DoMpoolLeakCheck, 0},
+		{"DoMissingActor", "STRESS_WEIGHT_MISSING_ACTOR", DoMissingActor, 0},
+		{"DoDelegatedSend", "STRESS_WEIGHT_DELEGATED_SEND", DoDelegatedSend, 0},
+		{"DoEthTxMapping", "STRESS_WEIGHT_ETH_TX_MAPPING", DoEthTxMapping, 0},
+		{"DoEthTxIndexCheck", "STRESS_WEIGHT_ETH_TX_INDEX", DoEthTxIndexCheck, 0},
+		{"DoMixedNonceRace", "STRESS_WEIGHT_MIXED_NONCE", DoMixedNonceRace, 0},
 		{"DoChainMonitor", "STRESS_WEIGHT_CHAIN_MONITOR", DoChainMonitor, 0},
+		{"DoNullRoundCheck", "STRESS_WEIGHT_NULL_ROUND", DoNullRoundCheck, 0},
+		{"DoBaseFeeBurnCheck", "STRESS_WEIGHT_BURN_CHECK", DoBaseFeeBurnCheck, 0},
+		{"DoBlockMessagesConsistency", "STRESS_WEIGHT_BLOCK_MSGS", DoBlockMessagesConsistency, 0},
+		{"DoWalletBalanceDrift", "STRESS_WEIGHT_BALANCE_DRIFT", DoWalletBalanceDrift, 0},
+		{"DoStateContentCheck", "STRESS_WEIGHT_STATE_CONTENT", DoStateContentCheck, 0},
+		{"DoRestartRecoveryCheck", "STRESS_WEIGHT_RESTART_CHECK", DoRestartRecoveryCheck, 0},
+		{"DoRPCFuzz", "STRESS_WEIGHT_RPC_FUZZ", DoRPCFuzz, 0},
+		{"DoMarketBalanceConsistency", "STRESS_WEIGHT_MARKET_BALANCE", DoMarketBalanceConsistency, 0},
+		{"DoSimpleCoinMonitor", "STRESS_WEIGHT_SIMPLECOIN_MONITOR", DoSimpleCoinMonitor, 0},
+		{"DoCreate2Spam", "STRESS_WEIGHT_CREATE2_SPAM", DoCreate2Spam, 0},
+		{"DoNonceReconcile", "STRESS_WEIGHT_NONCE_RECONCILE", DoNonceReconcile, 0},
+		{"DoSplitBrain", "STRESS_WEIGHT_SPLIT_BRAIN", DoSplitBrain, 0},
+		{"DoGasEstimateAudit", "STRESS_WEIGHT_GAS_ESTIMATE", DoGasEstimateAudit, 0},
+		{"DoF3Monitor", "STRESS_WEIGHT_F3_MONITOR", DoF3Monitor, 0},
+		{"DoActorStateDiff", "STRESS_WEIGHT_ACTOR_DIFF", DoActorStateDiff, 0},
+		{"DoFeeSpike", "STRESS_WEIGHT_FEE_SPIKE", DoFeeSpike, 0},
+		{"DoMempoolFlood", "STRESS_WEIGHT_MEMPOOL_FLOOD", DoMempoolFlood, 0},
+		{"DoRevertReason", "STRESS_WEIGHT_REVERT_REASON", DoRevertReason, 0},
+		{"DoReentrancy", "STRESS_WEIGHT_REENTRANCY", DoReentrancy, 0},
+		{"DoSelfDestructRecreate", "STRESS_WEIGHT_SELFDESTRUCT_RECREATE", DoSelfDestructRecreate, 0},
+		{"DoEthBlockAudit", "STRESS_WEIGHT_ETH_BLOCK", DoEthBlockAudit, 0},
+		{"DoEthLogFilter", "STRESS_WEIGHT_LOG_FILTER", DoEthLogFilter, 0},
+		{"DoEthBalanceCheck", "STRESS_WEIGHT_ETH_BALANCE", DoEthBalanceCheck, 0},
+		{"DoStateReplay", "STRESS_WEIGHT_STATE_REPLAY", DoStateReplay, 0},
+		{"DoParentMessageOrder", "STRESS_WEIGHT_MSG_ORDER", DoParentMessageOrder, 0},
 		// FVM/EVM contract stress vectors
 		{"DoDeployContracts", "STRESS_WEIGHT_DEPLOY", DoDeployContracts, 2},
+		{"DoDeployFanIn", "STRESS_WEIGHT_DEPLOY_FANIN", DoDeployFanIn, 0},
 		{"DoContractCall", "STRESS_WEIGHT_CONTRACT_CALL", DoContractCall, 3},
 		{"DoSelfDestructCycle", "STRESS_WEIGHT_SELFDESTRUCT", DoSelfDestructCycle, 1},
 		{"DoConflictingContractCalls", "STRESS_WEIGHT_CONTRACT_RACE", DoConflictingContractCalls, 2},
 		// Resource stress vectors
 		{"DoGasGuzzler", "STRESS_WEIGHT_GAS_GUZZLER", DoGasGuzzler, 0},
+		{"DoBlockGasLimit", "STRESS_WEIGHT_BLOCK_GAS_LIMIT", DoBlockGasLimit, 0},
 		{"DoLogBlaster", "STRESS_WEIGHT_LOG_BLASTER", DoLogBlaster, 0},
 		{"DoMemoryBomb", "STRESS_WEIGHT_MEMORY_BOMB", DoMemoryBomb, 0},
 		{"DoStorageSpam", "STRESS_WEIGHT_STORAGE_SPAM", DoStorageSpam, 0},
+		{"DoStateGrowthMonitor", "STRESS_WEIGHT_STATE_GROWTH", DoStateGrowthMonitor, 0},
 		// Network chaos / reorg vectors
 		{"DoReorgChaos", "STRESS_WEIGHT_REORG", DoReorgChaos, 0},
+		{"DoReorgDeployRace", "STRESS_WEIGHT_REORG_DEPLOY", DoReorgDeployRace, 0},
+		{"DoPartitionMatrix", "STRESS_WEIGHT_PARTITION_MATRIX", DoPartitionMatrix, 0},
 	}
 
-	deck = nil
+	known := make([]string, 0, len(actions))
 	for _, a := range actions {
+		known = append(known, a.envVar)
+	}
+	warnUnknownWeights(known)
+
+	numNodes := len(configuredNodeNames())
+	weights := make([]int, len(actions))
+	total := 0
+	for i, a := range actions {
+		if a.fn == nil {
+			log.Fatalf("[init] FATAL: action %s has no function", a.name)
+		}
 		w := envInt(a.envVar, a.defWeight)
+		if dryRun && networkChaosActions[a.name] && w > 0 {
+			log.Printf("[init] dry run: skipping %s (partitions the network)", a.name)
+			continue
+		}
+		if w > 0 && numNodes < minNodesForAction[a.name] {
+			log.Printf("[init] WARN: %s needs at least %d nodes but %d configured; it will no-op",
+				a.name, minNodesForAction[a.name], numNodes)
+		}
+		weights[i] = w
+		total += w
+	}
+
+	deck = nil
+	for i, a := range actions {
+		w := weights[i]
 		if w > 0 {
-			log.Printf("[init] action %s: weight=%d", a.name, w)
+			log.Printf("[init] action %s: weight=%d (%.1f%%)", a.name, w, 100*float64(w)/float64(total))
 		}
-		for i := 0; i < w; i++ {
+		for j := 0; j < w; j++ {
 			deck = append(deck, namedAction{name: a.name, fn: a.fn})
 		}
 	}
@@ -277,6 +423,61 @@ func buildDeck() {
 	log.Printf("[init] deck built with %d entries", len(deck))
 }
 
+// minNodesForAction lists actions that return immediately with fewer nodes
+// than they compare or partition; buildDeck warns when they are weighted
+// into a deck that cannot run them.
+var minNodesForAction = map[string]int{
+	"DoNullRoundCheck":           2,
+	"DoBaseFeeBurnCheck":         2,
+	"DoBlockMessagesConsistency": 2,
+	"DoStateContentCheck":        2,
+	"DoRestartRecoveryCheck":     2,
+	"DoMarketBalanceConsistency": 2,
+	"DoGasEstimateAudit":         2,
+	"DoActorStateDiff":           2,
+	"DoEthBlockAudit":            2,
+	"DoEthLogFilter":             2,
+	"DoStateReplay":              2,
+	"DoParentMessageOrder":       2,
+	"DoConflictingContractCalls": 2,
+	"DoDeployFanIn":              2,
+	"DoMixedNonceRace":           2,
+	"DoReorgChaos":               2,
+	"DoReorgDeployRace":          2,
+	"DoSplitBrain":               2,
+	"DoPartitionMatrix":          3,
+}
+
+// networkChaosActions disconnect peers, which mutates the cluster even
+// though they submit no messages; they are dropped from the deck in dry runs.
+var networkChaosActions = map[string]bool{
+	"DoReorgChaos":      true,
+	"DoReorgDeployRace": true,
+	"DoPartitionMatrix": true,
+	"DoSplitBrain":      true,
+}
+
+// warnUnknownWeights flags STRESS_WEIGHT_* variables that don't map to any
+// action. A typo like STRESS_WEIGHT_TRANSFERR would otherwise be silently
+// ignored and the run would use a different deck than intended.
+func warnUnknownWeights(known []string) {
+	recognized := make(map[string]bool, len(known))
+	for _, k := range known {
+		recognized[k] = true
+	}
+
+	for _, kv := range os.Environ() {
+		key, _, _ := strings.Cut(kv, "=")
+		if !strings.HasPrefix(key, "STRESS_WEIGHT_") || recognized[key] {
+			continue
+		}
+		sorted := append([]string{}, known...)
+		sort.Strings(sorted)
+		log.Printf("[init] WARN: unknown weight variable %s is ignored; recognized: %s",
+			key, strings.Join(sorted, ", "))
+	}
+}
+
 // ---------------------------------------------------------------------------
 // Main
 // ---------------------------------------------------------------------------
@@ -284,16 +485,39 @@ func buildDeck() {
 func main() {
 	log.SetFlags(log.LstdFlags | log.Lmicroseconds)
 	log.Println("[engine] stress engine starting")
+	if dryRun {
+		log.Println("[engine] DRY RUN: message submissions are logged and skipped")
+	}
+
+	checkAssertionIDs()
 
-	ctx, cancel = context.WithCancel(context.Background())
+	if deckCheck {
+		log.Println("[engine] DECK CHECK: validating STRESS_WEIGHT_* without connecting to nodes")
+		buildDeck()
+		return
+	}
+
+	// SIGINT/SIGTERM cancel ctx; the main loop then returns so the deferred
+	// connection teardown runs.
+	ctx, cancel = signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
 	defer cancel()
 
 	connectNodes()
+	defer closeNodes()
 	loadKeystore()
 	waitForChain()
+	if ctx.Err() != nil {
+		log.Println("[engine] shutdown requested before the chain was ready")
+		return
+	}
+	probeNodeVersions()
+	probeNodeCapabilities()
+	doGenesisCheck()
 	initNonces()
 	initContractBytecodes()
+	initMagnitudes()
 	buildDeck()
+	buildContractCallDeck()
 
 	lifecycle.SetupComplete(map[string]any{
 		"nodes":   len(nodes),
@@ -307,22 +531,152 @@ func main() {
 	actionCounts := make(map[string]int)
 	iteration := 0
 
-	for {
+	for ctx.Err() == nil {
 		idx := rngIntn(len(deck))
 		action := deck[idx]
 
 		debugLog("[engine] running: %s", action.name)
+		currentVector = action.name
 		action.fn()
 
 		actionCounts[action.name]++
 		iteration++
 
+		if iteration%gasResolveInterval == 0 {
+			resolveGasAccounting()
+		}
+
 		// Periodic summary every 500 iterations
 		if iteration%500 == 0 {
-			log.Printf("[engine] === iteration %d summary ===", iteration)
-			for name, count := range actionCounts {
-				log.Printf("[engine]   %s: %d", name, count)
-			}
+			logSummary(iteration, actionCounts)
 		}
 	}
+
+	log.Printf("[engine] shutting down after %d iterations", iteration)
+	logSummary(iteration, actionCounts)
+	lifecycle.SendEvent("engine_shutdown", map[string]any{
+		"iterations": iteration,
+		"actions":    actionCounts,
+	})
+}
+
+// ---------------------------------------------------------------------------
+// Summary reporting
+// ---------------------------------------------------------------------------
+
+// contractCoverage is the per-contract-type entry in the metrics JSON.
+type contractCoverage struct {
+	Deployed int `json:"deployed"`
+	Called   int `json:"called"`
+}
+
+// catchUpStats summarizes post-heal sync catch-up durations.
+type catchUpStats struct {
+	Count  int   `json:"count"`
+	MeanMs int64 `json:"mean_ms"`
+	MaxMs  int64 `json:"max_ms"`
+}
+
+// engineMetrics is the machine-readable snapshot logged with each summary.
+type engineMetrics struct {
+	Iteration     int                         `json:"iteration"`
+	Actions       map[string]int              `json:"actions"`
+	Contracts     map[string]contractCoverage `json:"contracts"`
+	BatchPushed   int                         `json:"batch_pushed"`
+	BatchPushRate float64                     `json:"batch_push_rate"`
+	GasByVector   map[string]int64            `json:"gas_by_vector"`
+	StorageSlots  int                         `json:"storage_slots_written"`
+	StateGrowth   float64                     `json:"state_growth_bytes_per_epoch"`
+	CatchUp       catchUpStats                `json:"catch_up"`
+	Assertions    map[string]assertionStat    `json:"assertions"`
+}
+
+// snapshotContractCoverage returns deploy/call counts for every known
+// contract type, including types that were never exercised.
+func snapshotContractCoverage() map[string]contractCoverage {
+	contractsMu.Lock()
+	defer contractsMu.Unlock()
+	cov := make(map[string]contractCoverage, len(contractTypes))
+	for _, ctype := range contractTypes {
+		cov[ctype] = contractCoverage{
+			Deployed: contractDeployCounts[ctype],
+			Called:   contractCallCounts[ctype],
+		}
+	}
+	return cov
+}
+
+// logSummary prints action counts and contract-type coverage in sorted order
+// so summaries from different runs can be diffed, followed by a JSON line.
+func logSummary(iteration int, actionCounts map[string]int) {
+	log.Printf("[engine] === iteration %d summary ===", iteration)
+	names := make([]string, 0, len(actionCounts))
+	for name := range actionCounts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		log.Printf("[engine]   %s: %d", name, actionCounts[name])
+	}
+
+	cov := snapshotContractCoverage()
+	ctypes := make([]string, 0, len(cov))
+	for ctype := range cov {
+		ctypes = append(ctypes, ctype)
+	}
+	sort.Strings(ctypes)
+	for _, ctype := range ctypes {
+		c := cov[ctype]
+		log.Printf("[engine]   contract %s: deployed=%d called=%d", ctype, c.Deployed, c.Called)
+	}
+
+	gas := snapshotGasByVector()
+	logGasByVector(gas)
+
+	pushed, rate := batchPushRate()
+	if pushed > 0 {
+		log.Printf("[engine]   batch push: %d msgs at %.1f msgs/sec", pushed, rate)
+	}
+
+	if stateGrowthRate > 0 {
+		log.Printf("[engine]   state growth: %.0f bytes/epoch (%d storage slots written)",
+			stateGrowthRate, storageSlotsWritten)
+	}
+
+	catchUp := catchUpSummary()
+	if catchUp.Count > 0 {
+		log.Printf("[engine]   catch-up: %d heals, mean=%dms max=%dms", catchUp.Count, catchUp.MeanMs, catchUp.MaxMs)
+	}
+
+	asserts := snapshotAssertionStats()
+	unreached := 0
+	for _, id := range assertionIDs {
+		st := asserts[id]
+		if st.Evaluated == 0 {
+			unreached++
+			continue
+		}
+		log.Printf("[engine]   assert %q: passed %d/%d", id, st.Passed, st.Evaluated)
+	}
+	if unreached > 0 {
+		log.Printf("[engine]   %d of %d assertions not yet evaluated", unreached, len(assertionIDs))
+	}
+
+	b, err := json.Marshal(engineMetrics{
+		Iteration:     iteration,
+		Actions:       actionCounts,
+		Contracts:     cov,
+		BatchPushed:   pushed,
+		BatchPushRate: rate,
+		GasByVector:   gas,
+		StorageSlots:  storageSlotsWritten,
+		StateGrowth:   stateGrowthRate,
+		CatchUp:       catchUp,
+		Assertions:    asserts,
+	})
+	if err != nil {
+		log.Printf("[engine] metrics marshal failed: %v", err)
+		return
+	}
+	log.Printf("[engine] metrics: %s", b)
 }
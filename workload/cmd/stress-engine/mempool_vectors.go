@@ -1,14 +1,28 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"log"
 	"sync"
+	"time"
 
-	"github.com/antithesishq/antithesis-sdk-go/assert"
+	"workload/internal/bls"
 
+	"github.com/antithesishq/antithesis-sdk-go/random"
+
+	"github.com/filecoin-project/go-address"
 	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+	builtintypes "github.com/filecoin-project/go-state-types/builtin"
 	"github.com/filecoin-project/go-state-types/crypto"
+	"github.com/filecoin-project/lotus/api"
 	"github.com/filecoin-project/lotus/chain/types"
+	"github.com/filecoin-project/lotus/chain/types/ethtypes"
+	"github.com/filecoin-project/lotus/lib/sigs"
+	_ "github.com/filecoin-project/lotus/lib/sigs/delegated"
+	"github.com/ipfs/go-cid"
 )
 
 // ===========================================================================
@@ -16,8 +30,14 @@ import (
 // ===========================================================================
 
 // DoTransferMarket sends a random amount of FIL from one wallet to another
-// via a random node.
+// via a random node. With STRESS_TRANSFER_BATCH > 1, it submits that many
+// random transfers per call through a single batched push.
 func DoTransferMarket() {
+	if transferBatchSize > 1 {
+		doTransferBatch()
+		return
+	}
+
 	fromAddr, fromKI := pickWallet()
 	toAddr, _ := pickWallet()
 
@@ -40,6 +60,34 @@ func DoTransferMarket() {
 	}
 }
 
+// transferBatchSize is the number of transfers DoTransferMarket submits per call.
+var transferBatchSize = envInt("STRESS_TRANSFER_BATCH", 1)
+
+// doTransferBatch builds transferBatchSize random transfers and submits them
+// to one node via pushMsgBatch.
+func doTransferBatch() {
+	nodeName, node := pickNode()
+
+	msgs := make([]*types.Message, 0, transferBatchSize)
+	kis := make([]*types.KeyInfo, 0, transferBatchSize)
+	for i := 0; i < transferBatchSize; i++ {
+		fromAddr, fromKI := pickWallet()
+		toAddr, _ := pickWallet()
+		if fromAddr == toAddr {
+			continue
+		}
+		amount := abi.NewTokenAmount(int64(rngIntn(100) + 1))
+		msgs = append(msgs, baseMsg(fromAddr, toAddr, amount))
+		kis = append(kis, fromKI)
+	}
+
+	endPhase := beginPhase("transfer-burst", map[string]any{"node": nodeName, "size": len(msgs)})
+	accepted := pushMsgBatch(node, msgs, kis, "transfer-batch")
+	endPhase()
+
+	debugLog("  [transfer] batch via %s: %d/%d accepted", nodeName, accepted, len(msgs))
+}
+
 // ===========================================================================
 // Vector 3: DoGasWar (Mempool)
 //
@@ -62,31 +110,29 @@ func DoGasWar() {
 		return
 	}
 
-	_, node := pickNode()
+	nodeName, node := pickNode()
 	currentNonce := nonces[fromAddr]
 
 	// Tx_A: low gas premium
-	msgA := baseMsg(fromAddr, toAddrA, abi.NewTokenAmount(1))
+	msgA := baseMsgWithGas(fromAddr, toAddrA, abi.NewTokenAmount(1),
+		defaultGasLimit, defaultGasFeeCap, abi.NewTokenAmount(100))
 	msgA.Nonce = currentNonce
-	msgA.GasPremium = abi.NewTokenAmount(100)
-	msgA.GasFeeCap = abi.NewTokenAmount(100_000)
 
 	smsgA := signMsg(msgA, fromKI)
 	if smsgA == nil {
 		return
 	}
 
-	_, errA := node.MpoolPush(ctx, smsgA)
+	cidA, errA := mpoolPush(node, smsgA)
 	if errA != nil {
 		log.Printf("[gas-war] Tx_A push failed: %v", errA)
 		return
 	}
 
 	// Tx_B: same nonce, much higher gas premium (replacement)
-	msgB := baseMsg(fromAddr, toAddrB, abi.NewTokenAmount(1))
+	msgB := baseMsgWithGas(fromAddr, toAddrB, abi.NewTokenAmount(1),
+		defaultGasLimit, big.Mul(defaultGasFeeCap, big.NewInt(2)), abi.NewTokenAmount(50_000)) // 500x higher
 	msgB.Nonce = currentNonce
-	msgB.GasPremium = abi.NewTokenAmount(50_000) // 500x higher
-	msgB.GasFeeCap = abi.NewTokenAmount(200_000)
 
 	smsgB := signMsg(msgB, fromKI)
 	if smsgB == nil {
@@ -94,13 +140,73 @@ func DoGasWar() {
 		return
 	}
 
-	_, errB := node.MpoolPush(ctx, smsgB)
+	cidB, errB := mpoolPush(node, smsgB)
 
 	// Regardless of replacement success, nonce is consumed
 	nonces[fromAddr]++
 
 	debugLog("  [gas-war] nonce=%d: Tx_A(low)=%v, Tx_B(high)=%v",
 		currentNonce, errA == nil, errB == nil)
+
+	if errB == nil {
+		verifyReplacement(nodeName, fromAddr, currentNonce, cidA, cidB)
+	}
+}
+
+// verifyReplacement asserts that after an accepted same-nonce replacement the
+// pool holds Tx_B, not Tx_A. If neither is pending both may already have been
+// mined, in which case exactly one of them may have landed on chain.
+func verifyReplacement(nodeName string, from address.Address, nonce uint64, cidA, cidB cid.Cid) {
+	node := nodes[nodeName]
+	details := map[string]any{
+		"node":      nodeName,
+		"node_type": nodeType(nodeName),
+		"from":      from.String(),
+		"nonce":     nonce,
+		"tx_a":      cidA.String(),
+		"tx_b":      cidB.String(),
+	}
+
+	pending, err := node.MpoolPending(ctx, types.EmptyTSK)
+	if err != nil {
+		log.Printf("[gas-war] MpoolPending failed on %s: %v", nodeName, err)
+		return
+	}
+	var inPool cid.Cid
+	for _, sm := range pending {
+		if sm.Message.From == from && sm.Message.Nonce == nonce {
+			inPool = sm.Cid()
+			break
+		}
+	}
+
+	if inPool.Defined() {
+		replaced := inPool == cidB
+		details["in_pool"] = inPool.String()
+		alwaysAssert(replaced, aidGasWarReplaced, details)
+		if !replaced {
+			log.Printf("[gas-war] REPLACEMENT LOST on %s: pool holds %s for nonce %d, expected Tx_B %s",
+				nodeName, cidStr(inPool), nonce, cidStr(cidB))
+		}
+		return
+	}
+
+	// Not pending: fall back to the chain.
+	landed := 0
+	for _, c := range []cid.Cid{cidA, cidB} {
+		lookup, err := node.StateSearchMsg(ctx, types.EmptyTSK, c, api.LookbackNoLimit, false)
+		if err == nil && lookup != nil {
+			landed++
+		}
+	}
+	if landed == 0 {
+		return // in flight between pool and chain
+	}
+	details["landed"] = landed
+	alwaysAssert(landed == 1, aidGasWarOneLanded, details)
+	if landed != 1 {
+		log.Printf("[gas-war] BOTH LANDED on %s for %s nonce %d", nodeName, from, nonce)
+	}
 }
 
 // ===========================================================================
@@ -172,11 +278,11 @@ func doDoubleSpend() {
 	wg.Add(2)
 	go func() {
 		defer wg.Done()
-		_, errA = nodes[nodeA].MpoolPush(ctx, smsgA)
+		_, errA = mpoolPush(nodes[nodeA], smsgA)
 	}()
 	go func() {
 		defer wg.Done()
-		_, errB = nodes[nodeB].MpoolPush(ctx, smsgB)
+		_, errB = mpoolPush(nodes[nodeB], smsgB)
 	}()
 	wg.Wait()
 
@@ -186,10 +292,14 @@ func doDoubleSpend() {
 	debugLog("[adversarial] double-spend: nodeA=%s err=%v, nodeB=%s err=%v", nodeA, errA, nodeB, errB)
 }
 
-// doInvalidSignature constructs a message with garbage signature bytes
-// and asserts it is immediately rejected.
+// doInvalidSignature constructs a message with a signature that cannot be
+// valid for its secp sender and asserts it is immediately rejected. Variants:
+//   - garbage: random secp-typed signature bytes
+//   - bls-mismatch: a well-formed BLS signature over the message
+//   - delegated-mismatch: a valid delegated signature from the sender's key
+//   - empty: a zero-length signature
 func doInvalidSignature() {
-	fromAddr, _ := pickWallet()
+	fromAddr, fromKI := pickWallet()
 	toAddr, _ := pickWallet()
 	if fromAddr == toAddr {
 		return
@@ -199,35 +309,69 @@ func doInvalidSignature() {
 
 	msg := baseMsg(fromAddr, toAddr, abi.NewTokenAmount(1))
 	msg.Nonce = nonces[fromAddr] // use real nonce so only the sig is wrong
+	msgBytes := msg.Cid().Bytes()
+
+	variants := []string{"garbage", "bls-mismatch", "delegated-mismatch", "empty"}
+	variant := variants[rngIntn(len(variants))]
 
-	// Generate random garbage signature
-	garbageSig := make([]byte, 65)
-	for i := range garbageSig {
-		garbageSig[i] = byte(rngIntn(256))
+	var sig crypto.Signature
+	switch variant {
+	case "garbage":
+		garbageSig := make([]byte, 65)
+		for i := range garbageSig {
+			garbageSig[i] = byte(rngIntn(256))
+		}
+		sig = crypto.Signature{Type: crypto.SigTypeSecp256k1, Data: garbageSig}
+	case "bls-mismatch":
+		seed := make([]byte, 32)
+		for i := range seed {
+			seed[i] = byte(rngIntn(256))
+		}
+		blsKey, err := bls.PrivateKeyFromSeed(seed)
+		if err != nil {
+			return
+		}
+		s, err := sigs.Sign(crypto.SigTypeBLS, blsKey, msgBytes)
+		if err != nil {
+			log.Printf("[adversarial] BLS signing failed: %v", err)
+			return
+		}
+		sig = *s
+	case "delegated-mismatch":
+		s, err := sigs.Sign(crypto.SigTypeDelegated, fromKI.PrivateKey, msgBytes)
+		if err != nil {
+			log.Printf("[adversarial] delegated signing failed: %v", err)
+			return
+		}
+		sig = *s
+	case "empty":
+		sig = crypto.Signature{Type: crypto.SigTypeSecp256k1, Data: []byte{}}
 	}
 
 	smsg := &types.SignedMessage{
-		Message: *msg,
-		Signature: crypto.Signature{
-			Type: crypto.SigTypeSecp256k1,
-			Data: garbageSig,
-		},
+		Message:   *msg,
+		Signature: sig,
 	}
 
-	_, err := node.MpoolPush(ctx, smsg)
+	_, err := mpoolPush(node, smsg)
+	if errors.Is(err, errDryRun) {
+		return
+	}
 
 	// The node MUST reject an invalid signature
 	rejected := err != nil
 
-	assert.Always(rejected, "Message with invalid signature was rejected", map[string]any{
+	alwaysAssert(rejected, aidInvalidSigRejected, map[string]any{
 		"node":     nodeName,
 		"from":     fromAddr.String(),
+		"variant":  variant,
+		"sig_type": sig.Type,
 		"rejected": rejected,
 		"error":    errStr(err),
 	})
 
 	if !rejected {
-		log.Printf("[adversarial] SAFETY VIOLATION: invalid signature accepted by %s!", nodeName)
+		log.Printf("[adversarial] SAFETY VIOLATION: invalid signature (%s) accepted by %s!", variant, nodeName)
 	}
 
 	// Do NOT increment nonce — the message was invalid
@@ -277,13 +421,904 @@ func doNonceRace() {
 	wg.Add(2)
 	go func() {
 		defer wg.Done()
-		nodes[nodeA].MpoolPush(ctx, smsgLow)
+		mpoolPush(nodes[nodeA], smsgLow)
 	}()
 	go func() {
 		defer wg.Done()
-		nodes[nodeB].MpoolPush(ctx, smsgHigh)
+		mpoolPush(nodes[nodeB], smsgHigh)
 	}()
 	wg.Wait()
 
 	nonces[fromAddr]++
 }
+
+// ===========================================================================
+// DoMissingActor (Safety — Sends to Non-existent Actors)
+//
+// Three sub-actions picked randomly, each targeting an address with no actor:
+//   1. Value send to a fresh f1 address — valid, implicitly creates an account
+//   2. Value send to a huge f0 ID — invalid, ID addresses cannot be created
+//   3. Method call on a huge f0 ID — invalid, no actor to dispatch to
+//
+// The node may reject at admission; otherwise every node must report the same
+// exit code once executed, and the invalid cases must never succeed.
+// ===========================================================================
+
+const (
+	missingActorIDBase   = 1 << 40 // far beyond any ID the devnet will allocate
+	missingActorGasLimit = 10_000_000
+)
+
+func DoMissingActor() {
+	subAction := rngIntn(3)
+	subNames := []string{"create-on-send", "send-to-missing-id", "call-missing-id"}
+	debugLog("  [missing-actor] sub-action: %s", subNames[subAction])
+
+	fromAddr, fromKI := pickWallet()
+	nodeName, node := pickNode()
+
+	var (
+		toAddr address.Address
+		err    error
+	)
+	method := abi.MethodNum(0)
+	switch subAction {
+	case 0:
+		pubkey := make([]byte, 65)
+		for i := range pubkey {
+			pubkey[i] = byte(rngIntn(256))
+		}
+		toAddr, err = address.NewSecp256k1Address(pubkey)
+	case 1:
+		toAddr, err = address.NewIDAddress(missingActorIDBase + random.GetRandom()%missingActorIDBase)
+	case 2:
+		toAddr, err = address.NewIDAddress(missingActorIDBase + random.GetRandom()%missingActorIDBase)
+		method = abi.MethodNum(rngIntn(100) + 2)
+	}
+	if err != nil {
+		return
+	}
+
+	msg := baseMsg(fromAddr, toAddr, abi.NewTokenAmount(1))
+	msg.Method = method
+	msg.GasLimit = missingActorGasLimit
+	msg.Nonce = nonces[fromAddr]
+
+	smsg := signMsg(msg, fromKI)
+	if smsg == nil {
+		return
+	}
+
+	msgCid, err := mpoolPush(node, smsg)
+	if err != nil {
+		// Rejection at admission is an acceptable outcome for every case
+		debugLog("  [missing-actor] %s rejected by %s: %v", subNames[subAction], nodeName, err)
+		return
+	}
+	nonces[fromAddr]++
+
+	waitCtx, waitCancel := context.WithTimeout(ctx, stateWaitTimeout)
+	result, err := node.StateWaitMsg(waitCtx, msgCid, 1, 200, false)
+	waitCancel()
+	if err != nil {
+		log.Printf("[missing-actor] StateWaitMsg failed: %v", err)
+		return
+	}
+
+	exitCodes := make(map[string][]string) // exit code -> []nodeName
+	for _, name := range nodeKeys {
+		lookup, err := nodes[name].StateSearchMsg(ctx, types.EmptyTSK, msgCid, 200, true)
+		if err != nil || lookup == nil {
+			continue // not synced yet — not a disagreement
+		}
+		code := lookup.Receipt.ExitCode.String()
+		exitCodes[code] = append(exitCodes[code], name)
+	}
+
+	consistent := len(exitCodes) <= 1
+
+	alwaysAssert(consistent, aidMissingActorConsistent, withDivergence(exitCodes, map[string]any{
+		"sub_action": subNames[subAction],
+		"to":         toAddr.String(),
+		"method":     method,
+		"exit_codes": exitCodes,
+	}))
+
+	if subAction != 0 {
+		failed := !result.Receipt.ExitCode.IsSuccess()
+		alwaysAssert(failed, aidMissingActorFails, map[string]any{
+			"sub_action": subNames[subAction],
+			"node":       nodeName,
+			"to":         toAddr.String(),
+			"method":     method,
+			"exit_code":  result.Receipt.ExitCode.String(),
+		})
+		if !failed {
+			log.Printf("[missing-actor] SAFETY VIOLATION: %s to %s succeeded via %s", subNames[subAction], toAddr, nodeName)
+		}
+	} else {
+		sometimesAssert(result.Receipt.ExitCode.IsSuccess(), aidMissingActorCreates, map[string]any{
+			"node":      nodeName,
+			"to":        toAddr.String(),
+			"exit_code": result.Receipt.ExitCode.String(),
+		})
+	}
+
+	debugLog("  [missing-actor] %s to %s via %s: exit=%s codes=%v",
+		subNames[subAction], toAddr, nodeName, result.Receipt.ExitCode, exitCodes)
+}
+
+// ===========================================================================
+// DoDelegatedSend (Signing — f4 Native Messages)
+//
+// Sends native Filecoin messages (via MpoolPush, not eth_sendRawTransaction)
+// from f410 delegated addresses signed with SigTypeDelegated. This covers the
+// intersection of the native and eth signing worlds: a delegated account
+// using the native message path. Wallets are created lazily, funded from a
+// secp wallet, and used once their placeholder actor exists on chain.
+// ===========================================================================
+
+const (
+	delegatedPoolSize = 4
+
+	// delegatedGasLimit covers both the funding send that creates an f4
+	// placeholder actor and an eth-style InvokeContract transfer from it;
+	// each costs more than a plain secp send.
+	delegatedGasLimit = 10_000_000
+)
+
+type delegatedWallet struct {
+	addr   address.Address
+	key    []byte
+	funded bool
+}
+
+var delegatedWallets []*delegatedWallet
+
+// delegatedFundAmount is 1 FIL, plenty for many tiny sends plus gas.
+var delegatedFundAmount = abi.TokenAmount(types.MustParseFIL("1"))
+
+func DoDelegatedSend() {
+	if len(delegatedWallets) < delegatedPoolSize {
+		createDelegatedWallet()
+		return
+	}
+
+	nodeName, node := pickNode()
+	w := readyDelegatedWallet(node)
+	if w == nil {
+		return
+	}
+
+	smsg, toID, err := delegatedTransfer(node, w)
+	if err != nil {
+		debugLog("  [delegated] building transfer from %s failed: %v", w.addr, err)
+		return
+	}
+
+	_, err = mpoolPush(node, smsg)
+	if errors.Is(err, errDryRun) {
+		return
+	}
+	accepted := err == nil
+	if accepted {
+		nonces[w.addr]++
+	}
+
+	sometimesAssert(accepted, aidDelegatedSendAccepted, map[string]any{
+		"node":      nodeName,
+		"node_type": nodeType(nodeName),
+		"from":      w.addr.String(),
+		"to":        toID.String(),
+		"error":     errStr(err),
+	})
+
+	debugLog("  [delegated] %s -> %s via %s accepted=%v err=%v", w.addr, toID, nodeName, accepted, err)
+}
+
+// readyDelegatedWallet picks a pooled f4 wallet whose placeholder actor
+// exists on chain, syncing its nonce the first time. Returns nil if the
+// chosen wallet is not funded yet.
+func readyDelegatedWallet(node api.FullNode) *delegatedWallet {
+	w := rngChoice(delegatedWallets)
+	if w.funded {
+		return w
+	}
+	if _, err := node.StateGetActor(ctx, w.addr, types.EmptyTSK); err != nil {
+		debugLog("  [delegated] %s not funded yet", w.addr)
+		return nil
+	}
+	n, err := node.MpoolGetNonce(ctx, w.addr)
+	if err != nil {
+		return nil
+	}
+	w.funded = true
+	nonces[w.addr] = n
+	return w
+}
+
+// delegatedTransfer builds and signs a small eth-style value transfer from w
+// to a secp wallet addressed by its masked ID (eth-expressible).
+func delegatedTransfer(node api.FullNode, w *delegatedWallet) (*types.SignedMessage, address.Address, error) {
+	toAddr, _ := pickWallet()
+	toID, err := node.StateLookupID(ctx, toAddr, types.EmptyTSK)
+	if err != nil {
+		return nil, address.Undef, err
+	}
+
+	msg := baseMsg(w.addr, toID, abi.NewTokenAmount(int64(rngIntn(100)+1)))
+	msg.Method = builtintypes.MethodsEVM.InvokeContract
+	msg.GasLimit = delegatedGasLimit
+	msg.Nonce = nonces[w.addr]
+
+	smsg, err := signDelegatedMsg(msg, w.key)
+	if err != nil {
+		return nil, address.Undef, err
+	}
+	return smsg, toID, nil
+}
+
+// createDelegatedWallet generates a new f4 wallet from engine randomness and
+// funds it from a random secp wallet, which creates its placeholder actor.
+func createDelegatedWallet() {
+	key := make([]byte, 32)
+	for i := range key {
+		key[i] = byte(rngIntn(256))
+	}
+	addr, _, err := deriveFilAddr(key)
+	if err != nil {
+		log.Printf("[delegated] deriveFilAddr failed: %v", err)
+		return
+	}
+
+	fromAddr, fromKI := pickWallet()
+	_, node := pickNode()
+	msg := baseMsg(fromAddr, addr, delegatedFundAmount)
+	msg.GasLimit = delegatedGasLimit
+	if !pushMsg(node, msg, fromKI, "delegated-fund") {
+		return
+	}
+
+	delegatedWallets = append(delegatedWallets, &delegatedWallet{addr: addr, key: key})
+	log.Printf("[delegated] created and funded f4 wallet %s", addr)
+}
+
+// ===========================================================================
+// DoEthTxMapping (Eth API — Tx Hash ↔ Message CID Translation)
+//
+// Every delegated-signed message has both a native CID and an eth tx hash.
+// Submits an eth-style transfer from a pooled f4 wallet, waits for it to
+// land, then checks on every node that the eth view (hash → CID, tx, receipt)
+// and the native view (StateSearchMsg) describe the same execution. A bug in
+// the translation layer would silently desync the two.
+// ===========================================================================
+
+func DoEthTxMapping() {
+	if len(delegatedWallets) < delegatedPoolSize {
+		createDelegatedWallet()
+		return
+	}
+
+	nodeName, node := pickNode()
+	w := readyDelegatedWallet(node)
+	if w == nil {
+		return
+	}
+
+	smsg, _, err := delegatedTransfer(node, w)
+	if err != nil {
+		debugLog("  [eth-mapping] building transfer from %s failed: %v", w.addr, err)
+		return
+	}
+	txHash, err := delegatedTxHash(smsg)
+	if err != nil {
+		log.Printf("[eth-mapping] computing tx hash failed: %v", err)
+		return
+	}
+	_, fromEth, err := deriveFilAddr(w.key)
+	if err != nil {
+		return
+	}
+
+	msgCid, err := mpoolPush(node, smsg)
+	if err != nil {
+		debugLog("  [eth-mapping] push rejected by %s: %v", nodeName, err)
+		return
+	}
+	nonces[w.addr]++
+
+	waitCtx, waitCancel := context.WithTimeout(ctx, stateWaitTimeout)
+	_, err = node.StateWaitMsg(waitCtx, msgCid, 1, 200, false)
+	waitCancel()
+	if err != nil {
+		log.Printf("[eth-mapping] StateWaitMsg failed: %v", err)
+		return
+	}
+
+	for _, name := range nodeKeys {
+		n := nodes[name]
+
+		mappedCid, err := n.EthGetMessageCidByTransactionHash(ctx, &txHash)
+		if err != nil || mappedCid == nil {
+			debugLog("  [eth-mapping] %s has no CID for %s yet: %v", name, txHash, err)
+			continue
+		}
+		ethTx, err := n.EthGetTransactionByHash(ctx, &txHash)
+		if err != nil || ethTx == nil {
+			debugLog("  [eth-mapping] %s has no tx for %s yet: %v", name, txHash, err)
+			continue
+		}
+		receipt, err := n.EthGetTransactionReceipt(ctx, txHash)
+		if err != nil || receipt == nil {
+			debugLog("  [eth-mapping] %s has no receipt for %s yet: %v", name, txHash, err)
+			continue
+		}
+		lookup, err := n.StateSearchMsg(ctx, types.EmptyTSK, msgCid, 200, true)
+		if err != nil || lookup == nil {
+			debugLog("  [eth-mapping] %s has no native lookup for %s yet: %v", name, msgCid, err)
+			continue
+		}
+
+		nativeOK := lookup.Receipt.ExitCode.IsSuccess()
+		ethOK := receipt.Status == 1
+		consistent := *mappedCid == msgCid &&
+			ethTx.Hash == txHash &&
+			ethTx.From == fromEth &&
+			uint64(ethTx.Nonce) == smsg.Message.Nonce &&
+			nativeOK == ethOK
+
+		alwaysAssert(consistent, aidEthTxMapping, map[string]any{
+			"node":         name,
+			"node_type":    nodeType(name),
+			"tx_hash":      txHash.String(),
+			"msg_cid":      msgCid.String(),
+			"mapped_cid":   mappedCid.String(),
+			"eth_from":     ethTx.From.String(),
+			"want_from":    fromEth.String(),
+			"eth_nonce":    uint64(ethTx.Nonce),
+			"msg_nonce":    smsg.Message.Nonce,
+			"eth_status":   uint64(receipt.Status),
+			"native_exit":  lookup.Receipt.ExitCode.String(),
+			"native_epoch": lookup.Height,
+		})
+
+		if !consistent {
+			log.Printf("[eth-mapping] ETH/NATIVE MISMATCH on %s: hash=%s cid=%s mapped=%s from=%s nonce=%d status=%d exit=%s",
+				name, txHash, msgCid, mappedCid, ethTx.From, ethTx.Nonce, receipt.Status, lookup.Receipt.ExitCode)
+		}
+	}
+
+	debugLog("  [eth-mapping] OK: %s <-> %s checked on %d nodes", txHash, cidStr(msgCid), len(nodeKeys))
+}
+
+// ===========================================================================
+// DoEthTxIndexCheck (Eth API — Transaction Index Within a Block)
+//
+// Pushes a burst of eth-style transfers from the delegated pool to one node
+// so several land in the same tipset, then checks on every node that each
+// receipt's transactionIndex is its position in the eth block's transaction
+// list (which is 0..n-1 by construction) and that every node agrees.
+// Placement can legitimately differ across nodes until the inclusion tipset
+// is final, so the checks wait (bounded) for finality and only cover
+// transactions included at or below the finalized height.
+// ===========================================================================
+
+const (
+	ethTxIndexBurst   = 6               // transfers pushed back-to-back per invocation
+	ethTxIndexMaxWait = 3 * time.Minute // total wait for inclusion and finality
+)
+
+func DoEthTxIndexCheck() {
+	if len(delegatedWallets) < delegatedPoolSize {
+		createDelegatedWallet()
+		return
+	}
+
+	nodeName, node := pickNode()
+	var hashes []ethtypes.EthHash
+	var lastCid cid.Cid
+	for i := 0; i < ethTxIndexBurst; i++ {
+		w := readyDelegatedWallet(node)
+		if w == nil {
+			continue
+		}
+		smsg, _, err := delegatedTransfer(node, w)
+		if err != nil {
+			continue
+		}
+		txHash, err := delegatedTxHash(smsg)
+		if err != nil {
+			continue
+		}
+		msgCid, err := mpoolPush(node, smsg)
+		if err != nil {
+			debugLog("  [eth-index] push rejected by %s: %v", nodeName, err)
+			continue
+		}
+		nonces[w.addr]++
+		hashes = append(hashes, txHash)
+		lastCid = msgCid
+	}
+	if len(hashes) < 2 {
+		return
+	}
+
+	waitCtx, waitCancel := context.WithTimeout(ctx, ethTxIndexMaxWait)
+	defer waitCancel()
+	lookup, err := node.StateWaitMsg(waitCtx, lastCid, 1, 200, false)
+	if err != nil {
+		log.Printf("[eth-index] StateWaitMsg failed: %v", err)
+		return
+	}
+	finalizedHeight, final := waitFinalized(waitCtx, lookup.Height)
+	if !final {
+		debugLog("  [eth-index] inclusion at %d not final within %s (finalized=%d), skipping",
+			lookup.Height, ethTxIndexMaxWait, finalizedHeight)
+		return
+	}
+
+	// placements[txHash]["block#index"] = []nodeName, for txs each node has indexed
+	placements := make(map[string]map[string][]string)
+	for _, name := range nodeKeys {
+		n := nodes[name]
+		blocks := make(map[ethtypes.EthHash][]string) // block hash -> tx hashes in block order
+		for _, h := range hashes {
+			receipt, err := n.EthGetTransactionReceipt(ctx, h)
+			if err != nil || receipt == nil {
+				continue // not indexed on this node yet
+			}
+			if abi.ChainEpoch(receipt.BlockNumber) > finalizedHeight {
+				continue // included later than the last; not final yet
+			}
+
+			txs, ok := blocks[receipt.BlockHash]
+			if !ok {
+				blk, err := n.EthGetBlockByHash(ctx, receipt.BlockHash, false)
+				if err != nil {
+					log.Printf("[eth-index] EthGetBlockByHash failed for %s: %v", name, err)
+					return
+				}
+				for _, tx := range blk.Transactions {
+					txs = append(txs, fmt.Sprint(tx))
+				}
+				blocks[receipt.BlockHash] = txs
+			}
+
+			pos := -1
+			for i, tx := range txs {
+				if tx == h.String() {
+					pos = i
+					break
+				}
+			}
+			indexOK := pos >= 0 && uint64(receipt.TransactionIndex) == uint64(pos)
+
+			alwaysAssert(indexOK, aidEthTxIndexMatches, map[string]any{
+				"node":        name,
+				"node_type":   nodeType(name),
+				"tx_hash":     h.String(),
+				"block_hash":  receipt.BlockHash.String(),
+				"block_txs":   len(txs),
+				"tx_index":    uint64(receipt.TransactionIndex),
+				"block_index": pos,
+			})
+
+			if !indexOK {
+				log.Printf("[eth-index] INDEX MISMATCH on %s: tx %s index=%d position=%d in block %s",
+					name, h, receipt.TransactionIndex, pos, receipt.BlockHash)
+			}
+			if placements[h.String()] == nil {
+				placements[h.String()] = make(map[string][]string)
+			}
+			placement := fmt.Sprintf("%s#%d", receipt.BlockHash, receipt.TransactionIndex)
+			placements[h.String()][placement] = append(placements[h.String()][placement], name)
+		}
+	}
+
+	for txHash, byPlacement := range placements {
+		agree := len(byPlacement) == 1
+		alwaysAssert(agree, aidEthTxIndexAgree, withDivergence(byPlacement, map[string]any{
+			"tx_hash":    txHash,
+			"placements": byPlacement,
+		}))
+
+		if !agree {
+			log.Printf("[eth-index] PLACEMENT DIVERGENCE for %s: %v", txHash, byPlacement)
+			return
+		}
+	}
+
+	debugLog("  [eth-index] OK: %d eth txs indexed consistently on %d nodes", len(hashes), len(nodeKeys))
+}
+
+// ===========================================================================
+// DoMpoolLeakCheck (Mempool — Cleanup After Inclusion)
+//
+// Submits a transfer, waits until it is included and a few epochs deep, then
+// checks that no node still lists it in MpoolPending. A message that lingers
+// after inclusion wastes mempool capacity and is re-gossiped forever. Before
+// flagging a leak, inclusion is re-checked on that node so a reorg that
+// legitimately returned the message to the pool is not reported.
+// ===========================================================================
+
+const mpoolLeakConfidence = 3 // epochs on top of inclusion before checking
+
+func DoMpoolLeakCheck() {
+	fromAddr, fromKI := pickWallet()
+	toAddr, _ := pickWallet()
+	if fromAddr == toAddr {
+		return
+	}
+	nodeName, node := pickNode()
+
+	msg := baseMsg(fromAddr, toAddr, abi.NewTokenAmount(1))
+	msg.Nonce = nonces[fromAddr]
+	smsg := signMsg(msg, fromKI)
+	if smsg == nil {
+		return
+	}
+	msgCid, err := mpoolPush(node, smsg)
+	if err != nil {
+		debugLog("  [mpool-leak] push rejected by %s: %v", nodeName, err)
+		return
+	}
+	nonces[fromAddr]++
+	trackMsgGas(msgCid)
+
+	waitCtx, waitCancel := context.WithTimeout(ctx, stateWaitTimeout)
+	_, err = node.StateWaitMsg(waitCtx, msgCid, mpoolLeakConfidence, 200, false)
+	waitCancel()
+	if err != nil {
+		log.Printf("[mpool-leak] StateWaitMsg failed: %v", err)
+		return
+	}
+
+	for _, name := range nodeKeys {
+		pending, err := nodes[name].MpoolPending(ctx, types.EmptyTSK)
+		if err != nil {
+			log.Printf("[mpool-leak] MpoolPending failed for %s: %v", name, err)
+			continue
+		}
+		lingering := false
+		for _, p := range pending {
+			if p.Cid() == msgCid {
+				lingering = true
+				break
+			}
+		}
+		if !lingering {
+			continue
+		}
+
+		// Re-check inclusion: after a reorg the message is legitimately pending again
+		lookup, err := nodes[name].StateSearchMsg(ctx, types.EmptyTSK, msgCid, 200, false)
+		if err != nil || lookup == nil {
+			debugLog("  [mpool-leak] %s pending on %s but no longer included (reorg)", cidStr(msgCid), name)
+			continue
+		}
+
+		alwaysAssert(false, aidMpoolLeak, map[string]any{
+			"node":            name,
+			"node_type":       nodeType(name),
+			"msg_cid":         msgCid.String(),
+			"included_height": lookup.Height,
+			"pending_count":   len(pending),
+		})
+		log.Printf("[mpool-leak] LEAK on %s: %s included at %d but still pending", name, cidStr(msgCid), lookup.Height)
+	}
+
+	debugLog("  [mpool-leak] checked %s across %d nodes", cidStr(msgCid), len(nodeKeys))
+}
+
+// ===========================================================================
+// DoMixedNonceRace (Mempool — Native vs Eth Nonce Unification)
+//
+// A delegated f4 account can submit through both the native path (MpoolPush
+// of a delegated-signed message) and the eth path (eth_sendRawTransaction).
+// Both share one account nonce. This sends a native message and a different
+// eth tx with the same nonce to two different nodes concurrently; at most
+// one of them may ever land on-chain.
+// ===========================================================================
+
+func DoMixedNonceRace() {
+	if len(nodeKeys) < 2 {
+		return
+	}
+	if len(delegatedWallets) < delegatedPoolSize {
+		createDelegatedWallet()
+		return
+	}
+
+	nodeA := nodeKeys[rngIntn(len(nodeKeys))]
+	nodeB := nodeKeys[rngIntn(len(nodeKeys))]
+	for nodeA == nodeB {
+		nodeB = nodeKeys[rngIntn(len(nodeKeys))]
+	}
+
+	w := readyDelegatedWallet(nodes[nodeA])
+	if w == nil {
+		return
+	}
+
+	// Two different transfers with the same nonce
+	native, _, err := delegatedTransfer(nodes[nodeA], w)
+	if err != nil {
+		return
+	}
+	eth, _, err := delegatedTransfer(nodes[nodeA], w)
+	if err != nil || eth.Cid() == native.Cid() {
+		return
+	}
+	raw, err := delegatedRawTx(eth)
+	if err != nil {
+		log.Printf("[mixed-nonce] encoding raw tx failed: %v", err)
+		return
+	}
+
+	var wg sync.WaitGroup
+	var errA, errB error
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		_, errA = mpoolPush(nodes[nodeA], native)
+	}()
+	go func() {
+		defer wg.Done()
+		_, errB = sendEthTx(nodes[nodeB], raw)
+	}()
+	wg.Wait()
+
+	debugLog("  [mixed-nonce] nonce=%d native via %s err=%v, eth via %s err=%v",
+		native.Message.Nonce, nodeA, errA, nodeB, errB)
+
+	// Resync: the nonce is consumed if either was accepted
+	if n, err := nodes[nodeA].MpoolGetNonce(ctx, w.addr); err == nil {
+		nonces[w.addr] = n
+	}
+	if errA != nil && errB != nil {
+		return
+	}
+
+	waitCid, waitNode := native.Cid(), nodes[nodeA]
+	if errA != nil {
+		waitCid, waitNode = eth.Cid(), nodes[nodeB]
+	}
+	waitCtx, waitCancel := context.WithTimeout(ctx, stateWaitTimeout)
+	_, err = waitNode.StateWaitMsg(waitCtx, waitCid, 1, 200, true)
+	waitCancel()
+	if err != nil {
+		log.Printf("[mixed-nonce] StateWaitMsg failed: %v", err)
+		return
+	}
+
+	for _, name := range nodeKeys {
+		landed := 0
+		for _, c := range []cid.Cid{native.Cid(), eth.Cid()} {
+			lookup, err := nodes[name].StateSearchMsg(ctx, types.EmptyTSK, c, 200, false)
+			if err == nil && lookup != nil {
+				landed++
+			}
+		}
+
+		alwaysAssert(landed <= 1, aidMixedNonceOneLands, map[string]any{
+			"node":       name,
+			"node_type":  nodeType(name),
+			"from":       w.addr.String(),
+			"nonce":      native.Message.Nonce,
+			"native_cid": native.Cid().String(),
+			"eth_cid":    eth.Cid().String(),
+			"landed":     landed,
+		})
+
+		if landed > 1 {
+			log.Printf("[mixed-nonce] BOTH LANDED on %s for %s nonce %d", name, w.addr, native.Message.Nonce)
+		}
+	}
+}
+
+// ===========================================================================
+// DoNonceReconcile (Engine Hygiene — Local Nonce Drift)
+//
+// Double-spend, nonce-race and gas-war vectors bump nonces[addr] whether or
+// not their message survives. A dropped or conflicting tx leaves the local
+// counter ahead of the chain, wedging the wallet behind a gap. This compares
+// each wallet's local nonce with MpoolGetNonce on every node and resets it to
+// the highest value any node reports. A gap is itself a useful signal that
+// conflicting txs were dropped.
+// ===========================================================================
+
+func DoNonceReconcile() {
+	gaps, behind := 0, 0
+	for _, addr := range addrs {
+		var maxNonce uint64
+		complete := true
+		for _, name := range nodeKeys {
+			n, err := nodes[name].MpoolGetNonce(ctx, addr)
+			if err != nil {
+				// A node holding pending txs may be unreachable; resetting
+				// without its view could reuse their nonces.
+				complete = false
+				break
+			}
+			maxNonce = max(maxNonce, n)
+		}
+		if !complete {
+			continue
+		}
+
+		local := nonces[addr]
+		if local == maxNonce {
+			continue
+		}
+		if local > maxNonce {
+			gaps++
+		} else {
+			behind++
+		}
+		log.Printf("[nonce-reconcile] %s local=%d network=%d, resetting", addr, local, maxNonce)
+		nonces[addr] = maxNonce
+	}
+
+	sometimesAssert(gaps > 0, aidNonceReconcileGap, map[string]any{
+		"wallets": len(addrs),
+		"gaps":    gaps,
+		"behind":  behind,
+	})
+
+	debugLog("  [nonce-reconcile] %d wallets checked: %d gaps, %d behind", len(addrs), gaps, behind)
+}
+
+// ===========================================================================
+// DoFeeSpike (Mempool — Base-Fee Escalation)
+//
+// Floods one node with transfers from many wallets whose gas limits are far
+// above what a transfer uses. Base fee follows the gas limit packed into
+// blocks, so a few epochs of this should push it above the genesis floor,
+// exercising base-fee adjustment and greedy block packing under congestion.
+// ===========================================================================
+
+const (
+	feeSpikeMsgs         = 200
+	feeSpikeGasLimit     = 1_000_000_000 // ~1/10 of the block gas limit
+	feeSpikeSampleEpochs = 5
+)
+
+func DoFeeSpike() {
+	if len(addrs) < 2 {
+		return
+	}
+	nodeName, node := pickNode()
+
+	genesis, err := node.ChainGetGenesis(ctx)
+	if err != nil {
+		log.Printf("[fee-spike] ChainGetGenesis failed for %s: %v", nodeName, err)
+		return
+	}
+	floor := genesis.Blocks()[0].ParentBaseFee
+	feeCap := big.Mul(defaultGasFeeCap, big.NewInt(10))
+
+	// Nonces are assigned here on the main goroutine; only the pushes fan out.
+	smsgs := make([]*types.SignedMessage, 0, feeSpikeMsgs)
+	for i := 0; i < feeSpikeMsgs; i++ {
+		fromAddr, fromKI := pickWallet()
+		toAddr, _ := pickWallet()
+		msg := baseMsgWithGas(fromAddr, toAddr, abi.NewTokenAmount(1), feeSpikeGasLimit, feeCap, defaultGasPremium)
+		msg.Nonce = nonces[fromAddr]
+		smsg := signMsg(msg, fromKI)
+		if smsg == nil {
+			continue
+		}
+		nonces[fromAddr]++
+		smsgs = append(smsgs, smsg)
+	}
+	if dryRun {
+		log.Printf("[dry-run] would flood %s with %d high-gas-limit transfers", nodeName, len(smsgs))
+		resyncNonces(node, smsgs)
+		return
+	}
+	defer beginPhase("fee-spike", map[string]any{"node": nodeName, "messages": len(smsgs)})()
+
+	accepted := pushConcurrent(node, smsgs, "fee-spike")
+	if accepted < len(smsgs) {
+		resyncNonces(node, smsgs)
+	}
+	log.Printf("[fee-spike] pushed %d/%d transfers (gas limit %d) via %s", accepted, len(smsgs), feeSpikeGasLimit, nodeName)
+
+	peak := floor
+	var samples []string
+	for i := 0; i < feeSpikeSampleEpochs; i++ {
+		waitForEpochsOnOther("", 1)
+		head, err := node.ChainHead(ctx)
+		if err != nil {
+			log.Printf("[fee-spike] ChainHead failed for %s: %v", nodeName, err)
+			continue
+		}
+		fee := head.Blocks()[0].ParentBaseFee
+		samples = append(samples, fee.String())
+		peak = big.Max(peak, fee)
+	}
+
+	rose := peak.GreaterThan(floor)
+	sometimesAssert(rose, aidFeeSpikeRose, map[string]any{
+		"node":     nodeName,
+		"accepted": accepted,
+		"floor":    floor.String(),
+		"peak":     peak.String(),
+		"samples":  samples,
+	})
+	debugLog("  [fee-spike] base fee floor=%s peak=%s samples=%v", floor, peak, samples)
+}
+
+// ===========================================================================
+// DoMempoolFlood (Mempool — Size Limits)
+//
+// Pushes a burst of valid transfers with consecutive nonces from a single
+// wallet to one node, well past what a block can hold and past typical
+// per-sender limits. The node must keep answering MpoolPending and its pool
+// must stay bounded: it should reject or evict, not grow without limit. The
+// wallet's nonce is reconciled from the node afterwards, since some of the
+// burst is expected to be refused.
+// ===========================================================================
+
+const (
+	mempoolFloodBurst      = 1500
+	mempoolFloodMaxPending = 50_000 // above Lotus's and Forest's default pool limits
+)
+
+func DoMempoolFlood() {
+	if len(addrs) < 2 {
+		return
+	}
+	nodeName, node := pickNode()
+	fromAddr, fromKI := pickWallet()
+	toAddr, _ := pickWallet()
+
+	msgs := make([]*types.Message, 0, mempoolFloodBurst)
+	kis := make([]*types.KeyInfo, 0, mempoolFloodBurst)
+	for i := 0; i < mempoolFloodBurst; i++ {
+		msgs = append(msgs, baseMsg(fromAddr, toAddr, abi.NewTokenAmount(1)))
+		kis = append(kis, fromKI)
+	}
+	defer beginPhase("mempool-flood", map[string]any{"node": nodeName, "from": fromAddr.String(), "burst": mempoolFloodBurst})()
+
+	accepted := pushMsgBatch(node, msgs, kis, "mempool-flood")
+
+	pending, err := node.MpoolPending(ctx, types.EmptyTSK)
+	fromPending := 0
+	for _, sm := range pending {
+		if sm.Message.From == fromAddr {
+			fromPending++
+		}
+	}
+	details := map[string]any{
+		"node":         nodeName,
+		"node_type":    nodeType(nodeName),
+		"from":         fromAddr.String(),
+		"burst":        mempoolFloodBurst,
+		"accepted":     accepted,
+		"pending":      len(pending),
+		"from_pending": fromPending,
+		"error":        errStr(err),
+	}
+
+	sometimesAssert(err == nil, aidMempoolFloodResponsive, details)
+	if err == nil {
+		bounded := len(pending) <= mempoolFloodMaxPending
+		alwaysAssert(bounded, aidMempoolFloodBounded, details)
+		if !bounded {
+			log.Printf("[mempool-flood] %s holds %d pending messages (limit %d)", nodeName, len(pending), mempoolFloodMaxPending)
+		}
+	} else {
+		log.Printf("[mempool-flood] MpoolPending failed on %s after flood: %v", nodeName, err)
+	}
+
+	// Reconcile so later vectors don't reuse or skip this wallet's nonces.
+	if n, err := node.MpoolGetNonce(ctx, fromAddr); err == nil {
+		nonces[fromAddr] = n
+	} else {
+		log.Printf("[mempool-flood] MpoolGetNonce failed for %s: %v", fromAddr, err)
+	}
+
+	debugLog("  [mempool-flood] %s accepted %d/%d from %s, pending=%d (from sender %d)",
+		nodeName, accepted, mempoolFloodBurst, fromAddr, len(pending), fromPending)
+}
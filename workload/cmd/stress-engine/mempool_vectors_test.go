@@ -0,0 +1,81 @@
+package main
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/lotus/chain/types"
+
+	"workload/internal/chain/chaintest"
+)
+
+func TestTransferMarketAdvancesNonces(t *testing.T) {
+	mock := chaintest.NewMockNode(mockChain(20, "root")...)
+	useMockNodes(t, map[string]*chaintest.MockNode{"lotus0": mock})
+	wallets := useMockWallets(t, 2)
+
+	// Self-transfers are skipped, so draw until enough pushes land.
+	for i := 0; i < 200 && len(mock.Pushed) < 10; i++ {
+		DoTransferMarket()
+	}
+	if len(mock.Pushed) < 10 {
+		t.Fatalf("only %d transfers pushed after 200 draws", len(mock.Pushed))
+	}
+
+	next := make(map[string]uint64)
+	for _, smsg := range mock.Pushed {
+		from := smsg.Message.From.String()
+		if smsg.Message.Nonce != next[from] {
+			t.Fatalf("%s pushed nonce %d, want %d", from, smsg.Message.Nonce, next[from])
+		}
+		next[from]++
+	}
+	for _, w := range wallets {
+		if nonces[w] != next[w.String()] {
+			t.Errorf("local nonce for %s is %d, want %d", w, nonces[w], next[w.String()])
+		}
+	}
+}
+
+func TestTransferMarketKeepsNonceOnReject(t *testing.T) {
+	mock := chaintest.NewMockNode(mockChain(20, "root")...)
+	mock.PushErr = errors.New("mpool rejected")
+	useMockNodes(t, map[string]*chaintest.MockNode{"lotus0": mock})
+	wallets := useMockWallets(t, 2)
+
+	for i := 0; i < 20; i++ {
+		DoTransferMarket()
+	}
+	for _, w := range wallets {
+		if nonces[w] != 0 {
+			t.Errorf("local nonce for %s advanced to %d after rejected pushes", w, nonces[w])
+		}
+	}
+}
+
+func TestPushMsgBatchCountsAcceptedPrefix(t *testing.T) {
+	mock := chaintest.NewMockNode(mockChain(20, "root")...)
+	mock.PushLimit = 3
+	useMockNodes(t, map[string]*chaintest.MockNode{"lotus0": mock})
+	wallets := useMockWallets(t, 2)
+
+	msgs := make([]*types.Message, 5)
+	kis := make([]*types.KeyInfo, 5)
+	for i := range msgs {
+		msgs[i] = baseMsg(wallets[0], wallets[1], abi.NewTokenAmount(1))
+		kis[i] = keystore[wallets[0]]
+	}
+
+	// The batch stops after three messages and reports no CIDs; the
+	// fallback must count those three once and not resend them.
+	if got := pushMsgBatch(mock, msgs, kis, "test"); got != 3 {
+		t.Fatalf("pushMsgBatch accepted %d, want 3", got)
+	}
+	if len(mock.Pushed) != 3 {
+		t.Fatalf("node holds %d messages, want 3", len(mock.Pushed))
+	}
+	if nonces[wallets[0]] != 3 {
+		t.Errorf("local nonce is %d after resync, want 3", nonces[wallets[0]])
+	}
+}
@@ -1,11 +1,18 @@
 package main
 
 import (
+	"bytes"
+	"context"
+	"fmt"
 	"log"
 	"time"
 
-	"github.com/antithesishq/antithesis-sdk-go/assert"
+	"github.com/filecoin-project/go-address"
 	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/builtin/v15/eam"
+	"github.com/filecoin-project/lotus/api"
+	"github.com/filecoin-project/lotus/chain/types"
+	"github.com/ipfs/go-cid"
 	"github.com/libp2p/go-libp2p/core/peer"
 )
 
@@ -33,8 +40,15 @@ const (
 	reorgPostHealPause    = 2 * time.Second  // brief pause after reconnect
 	reorgReconnectPause   = 3 * time.Second  // wait after emergency reconnect
 	reorgFallbackBlock    = 6 * time.Second  // fallback per-block sleep
+	reorgStrandedMax      = 5                // max transfers pushed to the isolated victim
+	reorgAnchorLag        = 3                // epochs below the main pre-heal head checked after a deep reorg
+	reorgFinalityWait     = 3 * time.Minute  // max wait for finality to pass the heal height
 )
 
+// reorgDepth, when positive, replaces the rapid 1-3 epoch cycles with a single
+// deep fork: the victim stays isolated for this many epochs (STRESS_REORG_DEPTH).
+var reorgDepth = envInt("STRESS_REORG_DEPTH", 0)
+
 func DoReorgChaos() {
 	if len(nodeKeys) < 2 {
 		return
@@ -44,10 +58,21 @@ func DoReorgChaos() {
 	victimName := rngChoice(nodeKeys)
 	victim := nodes[victimName]
 
+	if reorgDepth > 0 {
+		doDeepReorg(victimName)
+		return
+	}
+
 	// Random number of rapid split-heal cycles: 1-10
 	numCycles := rngIntn(reorgMaxCyclesPerCall) + 1
 
+	// Half the time, strand transfers in the victim's mempool while it is
+	// isolated; the heal must re-propose them rather than lose them.
+	strandTxs := !dryRun && rngIntn(2) == 0
+	var stranded []cid.Cid
+
 	log.Printf("[reorg-chaos] starting %d rapid partition cycles, victim=%s", numCycles, victimName)
+	defer beginPhase("reorg-chaos", map[string]any{"victim": victimName, "cycles": numCycles, "strand_txs": strandTxs})()
 
 	// Collect known node addresses for reliable reconnection
 	knownPeers := collectNodeAddrInfos(victimName)
@@ -66,7 +91,7 @@ func DoReorgChaos() {
 			for _, p := range knownPeers {
 				victim.NetConnect(ctx, p)
 			}
-			time.Sleep(reorgReconnectPause)
+			sleepCtx(reorgReconnectPause)
 			continue
 		}
 
@@ -89,6 +114,10 @@ func DoReorgChaos() {
 		log.Printf("[reorg-chaos] cycle %d/%d: SPLIT %s (disconnected %d/%d, isolated=%v)",
 			cycle+1, numCycles, victimName, disconnected, len(peers), isolated)
 
+		if strandTxs && isolated && stranded == nil {
+			stranded = pushStrandedTxs(victimName)
+		}
+
 		// === MINE: wait for 1-3 epochs on the main partition ===
 		blocksToWait := rngIntn(3) + 1
 		waitForEpochsOnOther(victimName, blocksToWait)
@@ -109,7 +138,7 @@ func DoReorgChaos() {
 			cycle+1, numCycles, victimName, reconnected, len(savedPeers))
 
 		// Brief pause for sync to begin before next cycle
-		time.Sleep(reorgPostHealPause)
+		sleepCtx(reorgPostHealPause)
 
 		successfulCycles++
 	}
@@ -120,9 +149,260 @@ func DoReorgChaos() {
 
 	// Wait for full convergence after all cycles
 	log.Printf("[reorg-chaos] waiting for convergence after %d cycles...", successfulCycles)
-	time.Sleep(reorgConvergeWait)
+	caughtUpIn := measureCatchUp(victimName)
+	if remaining := reorgConvergeWait - caughtUpIn; remaining > 0 {
+		sleepCtx(remaining)
+	}
 
 	verifyPostReorgState(victimName, successfulCycles)
+	verifyStrandedTxs(victimName, stranded)
+}
+
+// doDeepReorg isolates the victim for reorgDepth epochs in one cycle, then
+// checks that healing actually reorged it: when the main partition out-mined
+// the victim, none of the tipsets the victim built alone may remain on its
+// chain, and the main partition's chain must be on it instead. The main
+// head itself can still be displaced by a sibling block, so the check uses
+// its ancestor reorgAnchorLag epochs down and only requires that tipset's
+// blocks to be part of the victim's canonical tipset at that height.
+func doDeepReorg(victimName string) {
+	victim := nodes[victimName]
+	defer beginPhase("reorg-chaos", map[string]any{"victim": victimName, "depth": reorgDepth})()
+
+	knownPeers := collectNodeAddrInfos(victimName)
+	peers, err := victim.NetPeers(ctx)
+	if err != nil || len(peers) == 0 {
+		log.Printf("[reorg-chaos] deep: victim %s has no peers to cut (err=%v)", victimName, err)
+		return
+	}
+
+	forkHead, err := victim.ChainHead(ctx)
+	if err != nil {
+		log.Printf("[reorg-chaos] deep: ChainHead failed on %s: %v", victimName, err)
+		return
+	}
+	forkHeight := forkHead.Height()
+
+	// === PARTITION ===
+	for _, p := range peers {
+		victim.NetDisconnect(ctx, p.ID)
+	}
+	postPeers, err := victim.NetPeers(ctx)
+	isolated := err == nil && len(postPeers) == 0
+	log.Printf("[reorg-chaos] deep: SPLIT %s at height %d for %d epochs (isolated=%v)", victimName, forkHeight, reorgDepth, isolated)
+
+	waitForEpochsOnOther(victimName, reorgDepth)
+
+	// The peer manager or bootstrap can reconnect the victim during the
+	// window, in which case its "fork" may contain main-chain tipsets.
+	if prePeers, err := victim.NetPeers(ctx); err != nil || len(prePeers) > 0 {
+		isolated = false
+	}
+
+	// Snapshot both sides before healing.
+	var otherName string
+	for _, name := range nodeKeys {
+		if name != victimName {
+			otherName = name
+			break
+		}
+	}
+	victimHead, vErr := victim.ChainHead(ctx)
+	otherHead, oErr := nodes[otherName].ChainHead(ctx)
+	var victimOnly []*types.TipSet // tipsets the victim built while isolated
+	for ts := victimHead; vErr == nil && ts.Height() > forkHeight; {
+		victimOnly = append(victimOnly, ts)
+		if ts, vErr = victim.ChainGetTipSet(ctx, ts.Parents()); vErr != nil {
+			break
+		}
+	}
+
+	// === HEAL ===
+	for _, p := range peers {
+		victim.NetConnect(ctx, p)
+	}
+	for _, p := range knownPeers {
+		victim.NetConnect(ctx, p) // best-effort
+	}
+	log.Printf("[reorg-chaos] deep: HEAL %s (built %d tipsets alone), waiting for convergence...", victimName, len(victimOnly))
+	caughtUpIn := measureCatchUp(victimName)
+	if remaining := reorgConvergeWait - caughtUpIn; remaining > 0 {
+		sleepCtx(remaining)
+	}
+
+	verifyPostReorgState(victimName, 1)
+
+	if !isolated {
+		log.Printf("[reorg-chaos] deep: %s was not isolated for the whole window, skipping reorg check", victimName)
+		return
+	}
+
+	if vErr != nil || oErr != nil || len(victimOnly) == 0 {
+		log.Printf("[reorg-chaos] deep: no victim fork to check (victim_err=%v other_err=%v built=%d)", vErr, oErr, len(victimOnly))
+		return
+	}
+
+	if !nodeSupports(victimName, "ChainTipSetWeight") || !nodeSupports(otherName, "ChainTipSetWeight") {
+		return
+	}
+
+	// Only the lighter side must reorg; a heavier victim keeps its chain.
+	victimWeight, vErr := victim.ChainTipSetWeight(ctx, victimHead.Key())
+	otherWeight, oErr := nodes[otherName].ChainTipSetWeight(ctx, otherHead.Key())
+	if vErr != nil || oErr != nil {
+		log.Printf("[reorg-chaos] deep: ChainTipSetWeight failed (victim=%v other=%v)", vErr, oErr)
+		return
+	}
+	if !otherWeight.GreaterThan(victimWeight) {
+		debugLog("  [reorg-chaos] deep: victim %s was not out-mined (weight %s vs %s), no reorg expected",
+			victimName, victimWeight, otherWeight)
+		return
+	}
+
+	head, err := victim.ChainHead(ctx)
+	if err != nil {
+		log.Printf("[reorg-chaos] deep: post-heal ChainHead failed on %s: %v", victimName, err)
+		return
+	}
+	var kept []string
+	for _, ts := range victimOnly {
+		onChain, err := victim.ChainGetTipSetByHeight(ctx, ts.Height(), head.Key())
+		if err == nil && onChain.Key() == ts.Key() {
+			kept = append(kept, fmt.Sprintf("%d:%s", ts.Height(), ts.Key()))
+		}
+	}
+	anchorHeight := otherHead.Height() - reorgAnchorLag
+	if anchorHeight <= forkHeight {
+		anchorHeight = otherHead.Height()
+	}
+	anchor, err := nodes[otherName].ChainGetTipSetByHeight(ctx, anchorHeight, otherHead.Key())
+	if err != nil {
+		log.Printf("[reorg-chaos] deep: ChainGetTipSetByHeight(%d) failed on %s: %v", anchorHeight, otherName, err)
+		return
+	}
+	var reapplied bool
+	if anchor.Height() <= head.Height() {
+		onChain, err := victim.ChainGetTipSetByHeight(ctx, anchor.Height(), head.Key())
+		reapplied = err == nil && containsBlocks(onChain, anchor)
+	}
+
+	reorged := len(kept) == 0 && reapplied
+	alwaysAssert(reorged, aidReorgDeepReverted, map[string]any{
+		"victim":        victimName,
+		"victim_type":   nodeType(victimName),
+		"depth":         reorgDepth,
+		"fork_height":   forkHeight,
+		"victim_head":   fmt.Sprintf("%d:%s", victimHead.Height(), victimHead.Key()),
+		"other_head":    fmt.Sprintf("%d:%s", otherHead.Height(), otherHead.Key()),
+		"anchor":        fmt.Sprintf("%d:%s", anchor.Height(), anchor.Key()),
+		"victim_weight": victimWeight.String(),
+		"other_weight":  otherWeight.String(),
+		"reverted":      len(victimOnly) - len(kept),
+		"kept":          kept,
+		"reapplied":     reapplied,
+	})
+
+	if reorged {
+		log.Printf("[reorg-chaos] deep: OK: %s reverted %d tipsets above %d and adopted the main chain",
+			victimName, len(victimOnly), forkHeight)
+	} else {
+		log.Printf("[reorg-chaos] deep: NO REORG on %s: kept=%v reapplied=%v", victimName, kept, reapplied)
+	}
+}
+
+// containsBlocks reports whether every block of sub is also in ts.
+func containsBlocks(ts, sub *types.TipSet) bool {
+	have := make(map[cid.Cid]bool, len(ts.Cids()))
+	for _, c := range ts.Cids() {
+		have[c] = true
+	}
+	for _, c := range sub.Cids() {
+		if !have[c] {
+			return false
+		}
+	}
+	return true
+}
+
+// pushStrandedTxs submits a few transfers through the isolated victim, so
+// they exist only in its mempool, and returns their CIDs.
+func pushStrandedTxs(victimName string) []cid.Cid {
+	victim := nodes[victimName]
+	n := rngIntn(reorgStrandedMax) + 1
+
+	var cids []cid.Cid
+	for i := 0; i < n; i++ {
+		fromAddr, fromKI := pickWallet()
+		toAddr, _ := pickWallet()
+		if fromAddr == toAddr {
+			continue
+		}
+
+		msg := baseMsg(fromAddr, toAddr, abi.NewTokenAmount(int64(rngIntn(100)+1)))
+		msg.Nonce = nonces[fromAddr]
+		smsg := signMsg(msg, fromKI)
+		if smsg == nil {
+			continue
+		}
+		msgCid, err := mpoolPush(victim, smsg)
+		if err != nil {
+			log.Printf("[reorg-chaos] stranded push via %s failed: %v", victimName, err)
+			continue
+		}
+		nonces[fromAddr]++
+		cids = append(cids, msgCid)
+	}
+
+	log.Printf("[reorg-chaos] stranded %d/%d transfers in %s's mempool", len(cids), n, victimName)
+	return cids
+}
+
+// verifyStrandedTxs checks that every transfer pushed to the isolated victim
+// either landed on chain or is still pending somewhere after the heal.
+func verifyStrandedTxs(victimName string, stranded []cid.Cid) {
+	if len(stranded) == 0 {
+		return
+	}
+
+	pending := make(map[cid.Cid][]string) // msg CID -> nodes holding it
+	for _, name := range nodeKeys {
+		msgs, err := nodes[name].MpoolPending(ctx, types.EmptyTSK)
+		if err != nil {
+			log.Printf("[reorg-chaos] MpoolPending failed on %s: %v", name, err)
+			continue
+		}
+		for _, sm := range msgs {
+			pending[sm.Cid()] = append(pending[sm.Cid()], name)
+		}
+	}
+
+	for _, msgCid := range stranded {
+		var landedAt abi.ChainEpoch = -1
+		for _, name := range nodeKeys {
+			lookup, err := nodes[name].StateSearchMsg(ctx, types.EmptyTSK, msgCid, 200, false)
+			if err == nil && lookup != nil {
+				landedAt = lookup.Height
+				break
+			}
+		}
+
+		landed := landedAt >= 0
+		survived := landed || len(pending[msgCid]) > 0
+		alwaysAssert(survived, aidReorgStrandedTxSurvives, map[string]any{
+			"victim":      victimName,
+			"victim_type": nodeType(victimName),
+			"msg_cid":     msgCid.String(),
+			"landed":      landed,
+			"landed_at":   landedAt,
+			"pending_on":  pending[msgCid],
+		})
+
+		if !survived {
+			log.Printf("[reorg-chaos] LOST: stranded tx %s from %s neither landed nor pending", msgCid, victimName)
+		} else {
+			debugLog("  [reorg-chaos] stranded tx %s landed=%v pending_on=%v", msgCid, landed, pending[msgCid])
+		}
+	}
 }
 
 // collectNodeAddrInfos gets the listening addresses of all known nodes
@@ -156,18 +436,19 @@ func waitForEpochsOnOther(excludeNode string, n int) {
 		}
 	}
 	if watchName == "" {
-		time.Sleep(time.Duration(n) * reorgFallbackBlock)
+		sleepCtx(time.Duration(n) * reorgFallbackBlock)
 		return
 	}
 
 	startHead, err := nodes[watchName].ChainHead(ctx)
 	if err != nil {
-		time.Sleep(time.Duration(n) * reorgFallbackBlock)
+		sleepCtx(time.Duration(n) * reorgFallbackBlock)
 		return
 	}
 	targetHeight := startHead.Height() + abi.ChainEpoch(n)
 
-	deadline := time.After(reorgEpochTimeout)
+	// Deep forks need proportionally longer than the per-call default.
+	deadline := time.After(max(reorgEpochTimeout, time.Duration(n)*reorgFallbackBlock*3/2))
 	for {
 		select {
 		case <-deadline:
@@ -178,11 +459,99 @@ func waitForEpochsOnOther(excludeNode string, n int) {
 			if err == nil && head.Height() >= targetHeight {
 				return
 			}
-			time.Sleep(time.Second)
+			if !sleepCtx(time.Second) {
+				return
+			}
 		}
 	}
 }
 
+// Catch-up bound: a rejoined node must reach the head the rest of the network
+// had at heal time within catchUpBase plus catchUpPerEpoch per epoch of gap.
+const (
+	catchUpBase     = 60 * time.Second
+	catchUpPerEpoch = 10 * time.Second
+	catchUpPoll     = time.Second
+)
+
+// catchUpDurations records every measured catch-up, for the engine metrics.
+// Main goroutine only.
+var catchUpDurations []time.Duration
+
+// measureCatchUp measures how far the victim fell behind the rest of the
+// network during a partition and how long it takes to catch up after the
+// heal. Returns the time spent waiting.
+func measureCatchUp(victimName string) time.Duration {
+	start := time.Now()
+
+	victimHead, err := nodes[victimName].ChainHead(ctx)
+	if err != nil {
+		return 0
+	}
+	var target abi.ChainEpoch
+	for _, name := range nodeKeys {
+		if name == victimName {
+			continue
+		}
+		head, err := nodes[name].ChainHead(ctx)
+		if err == nil && head.Height() > target {
+			target = head.Height()
+		}
+	}
+	gap := target - victimHead.Height()
+	if gap <= 0 {
+		return 0
+	}
+
+	bound := catchUpBase + time.Duration(gap)*catchUpPerEpoch
+	caughtUp := false
+	for time.Since(start) < bound {
+		head, err := nodes[victimName].ChainHead(ctx)
+		if err == nil && head.Height() >= target {
+			caughtUp = true
+			break
+		}
+		if !sleepCtx(catchUpPoll) {
+			break
+		}
+	}
+	elapsed := time.Since(start)
+
+	alwaysAssert(caughtUp, aidReorgCatchUp, map[string]any{
+		"victim":     victimName,
+		"node_type":  nodeType(victimName),
+		"gap_epochs": gap,
+		"target":     target,
+		"elapsed_ms": elapsed.Milliseconds(),
+		"bound_ms":   bound.Milliseconds(),
+	})
+
+	if caughtUp {
+		catchUpDurations = append(catchUpDurations, elapsed)
+		log.Printf("[reorg-chaos] %s caught up %d epochs in %s", victimName, gap, elapsed.Round(time.Millisecond))
+	} else {
+		log.Printf("[reorg-chaos] %s FAILED to catch up %d epochs within %s", victimName, gap, bound)
+	}
+	return elapsed
+}
+
+// catchUpSummary returns the count, mean and max of recorded catch-ups in ms.
+func catchUpSummary() catchUpStats {
+	var st catchUpStats
+	var total time.Duration
+	for _, d := range catchUpDurations {
+		total += d
+		if ms := d.Milliseconds(); ms > st.MaxMs {
+			st.MaxMs = ms
+		}
+	}
+	st.Count = len(catchUpDurations)
+	if st.Count > 0 {
+		st.MeanMs = total.Milliseconds() / int64(st.Count)
+	}
+	return st
+}
+
 // verifyPostReorgState runs convergence checks after reorg cycles complete.
 // Verifies: network healed, finalized state consistent, no zombie state.
 func verifyPostReorgState(victimName string, cycles int) {
@@ -194,7 +563,7 @@ func verifyPostReorgState(victimName string, cycles int) {
 		}
 		hasPeers := len(peers) > 0
 
-		assert.Sometimes(hasPeers, "Network connectivity restored after reorg", map[string]any{
+		sometimesAssert(hasPeers, aidReorgConnectivity, map[string]any{
 			"node":       name,
 			"node_type":  nodeType(name),
 			"victim":     victimName,
@@ -236,14 +605,14 @@ func verifyPostReorgState(victimName string, cycles int) {
 
 	statesMatch := len(stateRoots) == 1
 
-	assert.Always(statesMatch, "Chain state is consistent after reorg", map[string]any{
+	alwaysAssert(statesMatch, aidReorgStateConsistent, withDivergence(stateRoots, map[string]any{
 		"victim":        victimName,
 		"height":        checkHeight,
 		"finalized_at":  finalizedHeight,
 		"unique_states": len(stateRoots),
 		"state_roots":   stateRoots,
 		"cycles":        cycles,
-	})
+	}))
 
 	// Check 3: Finalized height spread — nodes shouldn't be too far apart after convergence.
 	// Uses finalizedHeights collected above to avoid false positives from nodes legitimately
@@ -270,7 +639,7 @@ func verifyPostReorgState(victimName string, cycles int) {
 	spread := maxH - minH
 	acceptable := spread <= 10
 
-	assert.Sometimes(acceptable, "Node heights within acceptable range after reorg", map[string]any{
+	sometimesAssert(acceptable, aidReorgHeights, map[string]any{
 		"victim":  victimName,
 		"heights": finalizedHeights,
 		"spread":  spread,
@@ -280,7 +649,7 @@ func verifyPostReorgState(victimName string, cycles int) {
 	// Liveness: full convergence achieved
 	converged := statesMatch && acceptable
 
-	assert.Sometimes(converged, "Nodes converged after reorg", map[string]any{
+	sometimesAssert(converged, aidReorgConverged, map[string]any{
 		"victim":       victimName,
 		"cycles":       cycles,
 		"states_match": statesMatch,
@@ -295,3 +664,409 @@ func verifyPostReorgState(victimName string, cycles int) {
 			cycles, statesMatch, spread, finalizedHeights)
 	}
 }
+
+// ===========================================================================
+// DoReorgDeployRace (Consensus Integrity — Deploys Across a Reorg)
+//
+// Submits several contract deploys to a victim node, immediately isolates
+// it so the deploys may land only on the victim's fork, then heals. After
+// convergence and once finality has passed the heal height, each deploy must
+// have the same disposition on every node at the common finalized tipset:
+// fully applied (same actor code) or absent. A deploy that exists on some
+// nodes but not others is a state bug.
+// ===========================================================================
+
+const reorgDeployMax = 4 // max deploys submitted before the partition
+
+func DoReorgDeployRace() {
+	if len(nodeKeys) < 2 || len(contractTypes) == 0 {
+		return
+	}
+
+	victimName := rngChoice(nodeKeys)
+	victim := nodes[victimName]
+	knownPeers := collectNodeAddrInfos(victimName)
+	defer beginPhase("reorg-deploy", map[string]any{"victim": victimName})()
+
+	// Submit deploys to the victim only
+	type raceDeploy struct {
+		msgCid cid.Cid
+		ctype  string
+	}
+	var deploys []raceDeploy
+	numDeploys := rngIntn(reorgDeployMax) + 1
+	for i := 0; i < numDeploys; i++ {
+		ctype := rngChoice(contractTypes)
+		fromAddr, fromKI := pickWallet()
+		msgCid, ok := deployContract(victim, fromAddr, fromKI, contractBytecodes[ctype], "reorg-deploy-"+ctype)
+		if ok {
+			deploys = append(deploys, raceDeploy{msgCid: msgCid, ctype: ctype})
+		}
+	}
+	if len(deploys) == 0 {
+		return
+	}
+
+	// === PARTITION ===
+	peers, err := victim.NetPeers(ctx)
+	if err != nil {
+		log.Printf("[reorg-deploy] NetPeers failed for %s: %v", victimName, err)
+		return
+	}
+	for _, p := range peers {
+		victim.NetDisconnect(ctx, p.ID)
+	}
+	log.Printf("[reorg-deploy] SPLIT %s with %d pending deploys", victimName, len(deploys))
+
+	waitForEpochsOnOther(victimName, rngIntn(3)+1)
+
+	// === HEAL ===
+	for _, p := range peers {
+		victim.NetConnect(ctx, p)
+	}
+	for _, p := range knownPeers {
+		victim.NetConnect(ctx, p)
+	}
+	healHeight := maxHeadHeight()
+	log.Printf("[reorg-deploy] HEAL %s at height %d, waiting for convergence...", victimName, healHeight)
+	sleepCtx(reorgConvergeWait)
+
+	// === VERIFY: same disposition on every node at the common finalized
+	// tipset, once it is past the fork ===
+	finalizedHeight, finTsk, ok := waitFinalizedPastHeal("reorg-deploy", healHeight)
+	if !ok {
+		return
+	}
+
+	for _, d := range deploys {
+		dispositions := make(map[string][]string) // disposition -> []nodeName
+		for _, name := range nodeKeys {
+			disp, err := deployDisposition(nodes[name], d.msgCid, finTsk)
+			if err != nil {
+				log.Printf("[reorg-deploy] disposition lookup failed for %s: %v", name, err)
+				continue
+			}
+			dispositions[disp] = append(dispositions[disp], name)
+		}
+		if len(dispositions) == 0 {
+			continue
+		}
+
+		consistent := len(dispositions) == 1
+
+		alwaysAssert(consistent, aidReorgDeployAtomic, withDivergence(dispositions, map[string]any{
+			"victim":       victimName,
+			"ctype":        d.ctype,
+			"msg_cid":      d.msgCid.String(),
+			"heal_height":  healHeight,
+			"finalized_at": finalizedHeight,
+			"dispositions": dispositions,
+		}))
+
+		if !consistent {
+			log.Printf("[reorg-deploy] PARTIAL DEPLOY after reorg: %s (%s) %v", d.ctype, cidStr(d.msgCid), dispositions)
+		}
+	}
+
+	debugLog("  [reorg-deploy] OK: checked %d deploys after reorg (victim=%s)", len(deploys), victimName)
+}
+
+// deployDisposition describes what a node's state says about a deploy message
+// as of the given tipset: "absent", a failed exit code, or the created actor's code.
+func deployDisposition(node api.FullNode, msgCid cid.Cid, tsk types.TipSetKey) (string, error) {
+	lookup, err := node.StateSearchMsg(ctx, tsk, msgCid, 200, false)
+	if err != nil {
+		return "", err
+	}
+	if lookup == nil {
+		return "absent", nil
+	}
+	if !lookup.Receipt.ExitCode.IsSuccess() {
+		return "exit=" + lookup.Receipt.ExitCode.String(), nil
+	}
+
+	var ret eam.CreateExternalReturn
+	if err := ret.UnmarshalCBOR(bytes.NewReader(lookup.Receipt.Return)); err != nil {
+		return "", err
+	}
+	idAddr, err := address.NewIDAddress(ret.ActorID)
+	if err != nil {
+		return "", err
+	}
+	actor, err := node.StateGetActor(ctx, idAddr, tsk)
+	if err != nil {
+		return "actor-missing:" + idAddr.String(), nil
+	}
+	return "actor=" + idAddr.String() + ":" + actor.Code.String(), nil
+}
+
+// ===========================================================================
+// DoPartitionMatrix (Consensus Integrity — Partition Topologies)
+//
+// DoReorgChaos always isolates a single victim. This vector walks through a
+// fixed list of partition topologies across invocations — half/half splits,
+// one-vs-rest splits, and chain partitions where the ends can only reach
+// each other through the middle — with node placement drawn from the rng so
+// runs are reproducible. After each heal, once finality has passed the heal
+// height, all nodes must agree on the chain at the common finalized height.
+// ===========================================================================
+
+type partitionTopology struct {
+	name string
+	// groups returns node groups for the given (shuffled) node order.
+	groups func(names []string) [][]string
+	// linked reports whether groups i and j may talk during the partition.
+	linked func(i, j int) bool
+}
+
+func sameGroup(i, j int) bool { return i == j }
+
+var partitionTopologies = []partitionTopology{
+	{"split-half", func(names []string) [][]string {
+		mid := len(names) / 2
+		return [][]string{names[:mid], names[mid:]}
+	}, sameGroup},
+	{"split-one", func(names []string) [][]string {
+		return [][]string{names[:1], names[1:]}
+	}, sameGroup},
+	{"chain", func(names []string) [][]string {
+		groups := make([][]string, len(names))
+		for i, n := range names {
+			groups[i] = []string{n}
+		}
+		return groups
+	}, func(i, j int) bool { return i-j <= 1 && j-i <= 1 }},
+}
+
+// partitionRound selects the next topology; advanced once per invocation.
+var partitionRound int
+
+func DoPartitionMatrix() {
+	if len(nodeKeys) < 3 {
+		return
+	}
+
+	topo := partitionTopologies[partitionRound%len(partitionTopologies)]
+	partitionRound++
+
+	order := append([]string{}, nodeKeys...)
+	for i := len(order) - 1; i > 0; i-- {
+		j := rngIntn(i + 1)
+		order[i], order[j] = order[j], order[i]
+	}
+	groups := topo.groups(order)
+	defer beginPhase("partition-matrix", map[string]any{"topology": topo.name, "groups": groups})()
+
+	// === PARTITION: cut every link between groups that may not talk ===
+	cut, ok := partitionGroups("partition-matrix", groups, topo.linked)
+	if !ok {
+		return
+	}
+	log.Printf("[partition-matrix] SPLIT topology=%s groups=%v (cut %d links)", topo.name, groups, cut)
+
+	waitForEpochsOnOther("", rngIntn(3)+1)
+
+	// === HEAL: fully reconnect every node ===
+	healPartition()
+	healHeight := maxHeadHeight()
+	log.Printf("[partition-matrix] HEAL topology=%s at height %d, waiting for convergence...", topo.name, healHeight)
+	sleepCtx(reorgConvergeWait)
+
+	// === VERIFY: every node agrees on the tipset at the common finalized
+	// height, once it is past the forks ===
+	finalizedHeight, _, ok := waitFinalizedPastHeal("partition-matrix", healHeight)
+	if !ok {
+		return
+	}
+
+	tipsets := make(map[string][]string) // tipset key -> []nodeName
+	for _, name := range nodeKeys {
+		finTs, err := nodes[name].ChainGetFinalizedTipSet(ctx)
+		if err != nil {
+			log.Printf("[partition-matrix] ChainGetFinalizedTipSet failed for %s: %v", name, err)
+			return
+		}
+		ts, err := nodes[name].ChainGetTipSetByHeight(ctx, finalizedHeight, finTs.Key())
+		if err != nil {
+			log.Printf("[partition-matrix] ChainGetTipSetByHeight(%d) failed for %s: %v", finalizedHeight, name, err)
+			return
+		}
+		key := ts.Key().String()
+		tipsets[key] = append(tipsets[key], name)
+	}
+
+	converged := len(tipsets) == 1
+	alwaysAssert(converged, aidPartitionConverged, withDivergence(tipsets, map[string]any{
+		"topology":     topo.name,
+		"groups":       groups,
+		"heal_height":  healHeight,
+		"finalized_at": finalizedHeight,
+		"tipsets":      tipsets,
+	}))
+
+	if converged {
+		log.Printf("[partition-matrix] OK: converged after topology=%s at height %d", topo.name, finalizedHeight)
+	} else {
+		log.Printf("[partition-matrix] DIVERGENCE after topology=%s at height %d: %v", topo.name, finalizedHeight, tipsets)
+	}
+}
+
+// partitionGroups disconnects every node pair whose groups are not linked and
+// returns the number of links cut. It fails only if a peer ID is unavailable.
+func partitionGroups(tag string, groups [][]string, linked func(i, j int) bool) (int, bool) {
+	peerIDs := make(map[string]peer.ID)
+	for _, name := range nodeKeys {
+		id, err := nodes[name].ID(ctx)
+		if err != nil {
+			log.Printf("[%s] ID failed for %s: %v", tag, name, err)
+			return 0, false
+		}
+		peerIDs[name] = id
+	}
+
+	cut := 0
+	for i := range groups {
+		for j := range groups {
+			if i == j || linked(i, j) {
+				continue
+			}
+			for _, a := range groups[i] {
+				for _, b := range groups[j] {
+					if err := nodes[a].NetDisconnect(ctx, peerIDs[b]); err == nil {
+						cut++
+					}
+				}
+			}
+		}
+	}
+	return cut, true
+}
+
+// maxHeadHeight returns the highest head height any node reports.
+func maxHeadHeight() abi.ChainEpoch {
+	var maxH abi.ChainEpoch
+	for _, name := range nodeKeys {
+		if head, err := nodes[name].ChainHead(ctx); err == nil && head.Height() > maxH {
+			maxH = head.Height()
+		}
+	}
+	return maxH
+}
+
+// waitFinalizedPastHeal waits up to reorgFinalityWait for every node to
+// finalize healHeight, so checks at the finalized tipset cover the fork.
+// Returns false, after logging, if finality does not get there in time.
+func waitFinalizedPastHeal(tag string, healHeight abi.ChainEpoch) (abi.ChainEpoch, types.TipSetKey, bool) {
+	waitCtx, cancel := context.WithTimeout(ctx, reorgFinalityWait)
+	defer cancel()
+	if finalizedHeight, final := waitFinalized(waitCtx, healHeight); !final {
+		log.Printf("[%s] finality at %d has not passed heal height %d within %s, skipping check",
+			tag, finalizedHeight, healHeight, reorgFinalityWait)
+		return finalizedHeight, types.EmptyTSK, false
+	}
+	finalizedHeight, finTsk := getFinalizedHeight()
+	return finalizedHeight, finTsk, true
+}
+
+// healPartition reconnects every node to every other node (best-effort).
+func healPartition() {
+	for _, name := range nodeKeys {
+		for _, p := range collectNodeAddrInfos(name) {
+			nodes[name].NetConnect(ctx, p) // best-effort
+		}
+	}
+}
+
+// ===========================================================================
+// DoSplitBrain (Consensus Integrity — Competing Forks)
+//
+// DoReorgChaos isolates one victim, which then simply catches up. Here the
+// node set is split into two roughly equal halves that each keep mining on
+// their own fork for several epochs. After the heal the forks compete and
+// fork choice must settle on one chain: once finality has passed the heal
+// height, every node has to report the same state root at the common
+// finalized height.
+// ===========================================================================
+
+const (
+	splitBrainMinEpochs = 3
+	splitBrainMaxEpochs = 8
+)
+
+func DoSplitBrain() {
+	if len(nodeKeys) < 2 {
+		return
+	}
+
+	order := append([]string{}, nodeKeys...)
+	for i := len(order) - 1; i > 0; i-- {
+		j := rngIntn(i + 1)
+		order[i], order[j] = order[j], order[i]
+	}
+	mid := len(order) / 2
+	groups := [][]string{order[:mid], order[mid:]}
+	epochs := splitBrainMinEpochs + rngIntn(splitBrainMaxEpochs-splitBrainMinEpochs+1)
+	defer beginPhase("split-brain", map[string]any{"groups": groups, "epochs": epochs})()
+
+	// === PARTITION: two halves, no links across the divide ===
+	cut, ok := partitionGroups("split-brain", groups, sameGroup)
+	if !ok {
+		return
+	}
+	log.Printf("[split-brain] SPLIT groups=%v (cut %d links), mining %d epochs apart", groups, cut, epochs)
+
+	waitForEpochsOnOther("", epochs)
+
+	// Record each side's head so the report shows both forks advanced.
+	forkHeads := make(map[string]string)
+	for _, name := range nodeKeys {
+		if head, err := nodes[name].ChainHead(ctx); err == nil {
+			forkHeads[name] = fmt.Sprintf("%d:%s", head.Height(), head.Key())
+		}
+	}
+
+	// === HEAL ===
+	healPartition()
+	healHeight := maxHeadHeight()
+	log.Printf("[split-brain] HEAL at height %d, waiting for convergence...", healHeight)
+	sleepCtx(reorgConvergeWait)
+
+	// === VERIFY: one state root at the common finalized height, once it is
+	// past the forks ===
+	finalizedHeight, _, ok := waitFinalizedPastHeal("split-brain", healHeight)
+	if !ok {
+		return
+	}
+
+	roots := make(map[string][]string) // state root -> []nodeName
+	for _, name := range nodeKeys {
+		finTs, err := nodes[name].ChainGetFinalizedTipSet(ctx)
+		if err != nil {
+			log.Printf("[split-brain] ChainGetFinalizedTipSet failed for %s: %v", name, err)
+			return
+		}
+		ts, err := nodes[name].ChainGetTipSetByHeight(ctx, finalizedHeight, finTs.Key())
+		if err != nil {
+			log.Printf("[split-brain] ChainGetTipSetByHeight(%d) failed for %s: %v", finalizedHeight, name, err)
+			return
+		}
+		root := ts.ParentState().String()
+		roots[root] = append(roots[root], name)
+	}
+
+	converged := len(roots) == 1
+	alwaysAssert(converged, aidSplitBrainConverged, withDivergence(roots, map[string]any{
+		"groups":       groups,
+		"epochs":       epochs,
+		"fork_heads":   forkHeads,
+		"heal_height":  healHeight,
+		"finalized_at": finalizedHeight,
+		"state_roots":  roots,
+	}))
+
+	if converged {
+		log.Printf("[split-brain] OK: converged after %d-epoch split at height %d", epochs, finalizedHeight)
+	} else {
+		log.Printf("[split-brain] DIVERGENCE after %d-epoch split at height %d: %v", epochs, finalizedHeight, roots)
+	}
+}
@@ -0,0 +1,159 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"workload/internal/chain"
+)
+
+// ===========================================================================
+// DoRPCFuzz (RPC Surface — Malformed JSON-RPC Requests)
+//
+// Every other vector speaks well-formed JSON-RPC through the lotus client.
+// This one POSTs malformed payloads straight to a node's /rpc/v1 endpoint:
+// unknown methods, wrong parameter types, oversized parameters, deeply
+// nested JSON and truncated bodies. The node must answer with a JSON-RPC
+// error — never a result, a hang, or a crash — which exercises the request
+// decoding layer of both Lotus and Forest.
+// ===========================================================================
+
+const (
+	rpcFuzzTimeout     = 30 * time.Second
+	rpcFuzzMaxBody     = 1 << 20 // response bytes read
+	rpcFuzzOversized   = 4 << 20 // bytes in an oversized string param
+	rpcFuzzNestDepth   = 10_000
+	rpcFuzzProbeMethod = "Filecoin.ChainHead"
+)
+
+var (
+	rpcFuzzClient = &http.Client{Timeout: rpcFuzzTimeout}
+	rpcTokens     = make(map[string]string) // node -> JWT, read lazily
+)
+
+// rpcFuzzCase is one malformed request. mustError marks payloads no correct
+// server can answer with a result; the rest may legitimately be rejected at
+// the HTTP layer instead.
+type rpcFuzzCase struct {
+	name      string
+	mustError bool
+	body      func() []byte
+}
+
+var rpcFuzzCases = []rpcFuzzCase{
+	{"unknown-method", true, func() []byte {
+		return rpcRequest(fmt.Sprintf("Filecoin.NoSuchMethod%d", rngIntn(1_000_000)), "[]")
+	}},
+	{"wrong-param-types", true, func() []byte {
+		return rpcRequest("Filecoin.ChainGetTipSetByHeight", `["not-an-epoch", 42]`)
+	}},
+	{"oversized-param", false, func() []byte {
+		return rpcRequest("Filecoin.ChainGetBlock", `[{"/":"`+strings.Repeat("b", rpcFuzzOversized)+`"}]`)
+	}},
+	{"deep-nesting", false, func() []byte {
+		nested := strings.Repeat("[", rpcFuzzNestDepth) + strings.Repeat("]", rpcFuzzNestDepth)
+		return rpcRequest("Filecoin.ChainGetTipSet", "["+nested+"]")
+	}},
+	{"truncated-json", true, func() []byte {
+		full := rpcRequest(rpcFuzzProbeMethod, "[]")
+		return full[:1+rngIntn(len(full)-1)]
+	}},
+	{"params-not-array", true, func() []byte {
+		return rpcRequest("Filecoin.StateGetActor", `"f01"`)
+	}},
+}
+
+// rpcRequest builds a JSON-RPC 2.0 request with raw params.
+func rpcRequest(method, params string) []byte {
+	return []byte(fmt.Sprintf(`{"jsonrpc":"2.0","id":%d,"method":%q,"params":%s}`, rngIntn(1_000_000), method, params))
+}
+
+// rpcResponse is the subset of a JSON-RPC response the fuzzer inspects.
+type rpcResponse struct {
+	Result json.RawMessage `json:"result"`
+	Error  *struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+func DoRPCFuzz() {
+	nodeName, _ := pickNode()
+	fc := rpcFuzzCases[rngIntn(len(rpcFuzzCases))]
+	url := nodeConfig.RPCAddr(nodeName, "http")
+
+	status, body, err := rpcPost(url, nodeName, fc.body())
+	responded := err == nil
+	details := map[string]any{
+		"node":      nodeName,
+		"node_type": nodeType(nodeName),
+		"case":      fc.name,
+		"status":    status,
+	}
+	if err != nil {
+		details["error"] = err.Error()
+	}
+
+	var resp rpcResponse
+	parsed := responded && json.Unmarshal(body, &resp) == nil
+	properError := parsed && resp.Error != nil && len(resp.Result) == 0
+	gotResult := parsed && resp.Error == nil && len(resp.Result) > 0
+	if parsed && resp.Error != nil {
+		details["rpc_code"] = resp.Error.Code
+	}
+
+	sometimesAssert(properError, aidRPCFuzzError, details)
+
+	if fc.mustError {
+		alwaysAssert(!gotResult, aidRPCFuzzNoResult, details)
+		if gotResult {
+			log.Printf("[rpc-fuzz] %s returned a result for %s: %.200s", nodeName, fc.name, body)
+		}
+	}
+
+	// The node must still serve a well-formed request afterwards.
+	_, probe, probeErr := rpcPost(url, nodeName, rpcRequest(rpcFuzzProbeMethod, "[]"))
+	var probeResp rpcResponse
+	alive := probeErr == nil && json.Unmarshal(probe, &probeResp) == nil && len(probeResp.Result) > 0
+	sometimesAssert(alive, aidRPCFuzzAlive, details)
+
+	if !responded || !alive {
+		log.Printf("[rpc-fuzz] %s case=%s responded=%v alive=%v err=%v probe_err=%v",
+			nodeName, fc.name, responded, alive, err, probeErr)
+		return
+	}
+	debugLog("  [rpc-fuzz] OK: %s case=%s status=%d proper_error=%v", nodeName, fc.name, status, properError)
+}
+
+// rpcPost sends a raw JSON-RPC body to url and returns the HTTP status and
+// (truncated) response body.
+func rpcPost(url, nodeName string, payload []byte) (int, []byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(payload))
+	if err != nil {
+		return 0, nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	token, ok := rpcTokens[nodeName]
+	if !ok {
+		token = chain.NodeToken(nodeName)
+		rpcTokens[nodeName] = token
+	}
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	resp, err := rpcFuzzClient.Do(req)
+	if err != nil {
+		return 0, nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, rpcFuzzMaxBody))
+	return resp.StatusCode, body, err
+}
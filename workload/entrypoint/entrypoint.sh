@@ -10,7 +10,9 @@ log_warn() { echo -e "${YELLOW}[WORKLOAD]${NC} $1"; }
 
 # ── 1. Generate genesis wallets ──
 log_info "Generating pre-funded genesis wallets..."
-/opt/antithesis/genesis-prep --count 100 --out /shared/configs
+/opt/antithesis/genesis-prep --count 100 --out /shared/configs --key-type "${STRESS_KEY_TYPE:-secp256k1}" \
+    --balance-distribution "${STRESS_BALANCE_DISTRIBUTION:-uniform}" --keystore-balances \
+    --miners "${STRESS_GENESIS_MINERS:-0}" --format "${STRESS_KEYSTORE_FORMAT:-lotus}"
 log_info "Genesis wallet generation complete."
 
 # ── 2. Time sync ──
@@ -0,0 +1,147 @@
+// Package bls implements Filecoin's BLS12-381 signature scheme in pure Go,
+// so the workload can produce and check BLS signatures without linking
+// filecoin-ffi. Importing the package registers the signer with lotus's sigs
+// registry for crypto.SigTypeBLS, mirroring lotus/lib/sigs/bls.
+package bls
+
+import (
+	"crypto/rand"
+	"fmt"
+	"math/big"
+
+	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/crypto"
+	"github.com/filecoin-project/lotus/lib/sigs"
+)
+
+// DST is the hash-to-curve domain separation tag Filecoin uses for BLS.
+const DST = "BLS_SIG_BLS12381G2_XMD:SHA-256_SSWU_RO_NUL_"
+
+const (
+	PrivateKeyBytes = 32
+	PublicKeyBytes  = bls12381.SizeOfG1AffineCompressed
+	SignatureBytes  = bls12381.SizeOfG2AffineCompressed
+)
+
+// scalar decodes a private key. Like filecoin-ffi, keys are little-endian
+// and must already be reduced below the group order.
+func scalar(priv []byte) (*big.Int, error) {
+	if len(priv) != PrivateKeyBytes {
+		return nil, fmt.Errorf("bls: invalid private key length %d", len(priv))
+	}
+	be := make([]byte, PrivateKeyBytes)
+	for i := range priv {
+		be[PrivateKeyBytes-1-i] = priv[i]
+	}
+	s := new(big.Int).SetBytes(be)
+	if s.Sign() == 0 || s.Cmp(fr.Modulus()) >= 0 {
+		return nil, fmt.Errorf("bls: private key out of range")
+	}
+	return s, nil
+}
+
+// PrivateKeyFromSeed reduces 32 bytes of key material into a valid
+// little-endian private key. The same seed always yields the same key.
+func PrivateKeyFromSeed(seed []byte) ([]byte, error) {
+	if len(seed) != PrivateKeyBytes {
+		return nil, fmt.Errorf("bls: invalid seed length %d", len(seed))
+	}
+	var e fr.Element
+	e.SetBytes(seed) // reduces mod r
+	if e.IsZero() {
+		return nil, fmt.Errorf("bls: seed reduces to zero")
+	}
+	be := e.Bytes()
+	priv := make([]byte, PrivateKeyBytes)
+	for i := range be {
+		priv[PrivateKeyBytes-1-i] = be[i]
+	}
+	return priv, nil
+}
+
+// PublicKey returns the compressed G1 public key for a private key.
+func PublicKey(priv []byte) ([]byte, error) {
+	s, err := scalar(priv)
+	if err != nil {
+		return nil, err
+	}
+	var pk bls12381.G1Affine
+	pk.ScalarMultiplicationBase(s)
+	b := pk.Bytes()
+	return b[:], nil
+}
+
+// Sign returns the compressed G2 signature of msg.
+func Sign(priv []byte, msg []byte) ([]byte, error) {
+	s, err := scalar(priv)
+	if err != nil {
+		return nil, err
+	}
+	h, err := bls12381.HashToG2(msg, []byte(DST))
+	if err != nil {
+		return nil, err
+	}
+	var sig bls12381.G2Affine
+	sig.ScalarMultiplication(&h, s)
+	b := sig.Bytes()
+	return b[:], nil
+}
+
+// Verify checks sig over msg against a compressed public key.
+func Verify(sig []byte, pub []byte, msg []byte) error {
+	if len(sig) != SignatureBytes || len(pub) != PublicKeyBytes {
+		return fmt.Errorf("bls signature failed to verify")
+	}
+	var pk bls12381.G1Affine
+	if _, err := pk.SetBytes(pub); err != nil {
+		return fmt.Errorf("bls: bad public key: %w", err)
+	}
+	var s bls12381.G2Affine
+	if _, err := s.SetBytes(sig); err != nil {
+		return fmt.Errorf("bls: bad signature: %w", err)
+	}
+	h, err := bls12381.HashToG2(msg, []byte(DST))
+	if err != nil {
+		return err
+	}
+
+	// e(pk, H(m)) == e(g1, sig)  <=>  e(pk, H(m)) * e(-g1, sig) == 1
+	_, _, g1, _ := bls12381.Generators()
+	var negG1 bls12381.G1Affine
+	negG1.Neg(&g1)
+	ok, err := bls12381.PairingCheck([]bls12381.G1Affine{pk, negG1}, []bls12381.G2Affine{h, s})
+	if err != nil || !ok {
+		return fmt.Errorf("bls signature failed to verify")
+	}
+	return nil
+}
+
+// signer adapts the package to lotus's sigs.SigShim.
+type signer struct{}
+
+func (signer) GenPrivate() ([]byte, error) {
+	seed := make([]byte, PrivateKeyBytes)
+	if _, err := rand.Read(seed); err != nil {
+		return nil, fmt.Errorf("bls signature error generating random data")
+	}
+	return PrivateKeyFromSeed(seed)
+}
+
+func (signer) ToPublic(priv []byte) ([]byte, error) {
+	return PublicKey(priv)
+}
+
+func (signer) Sign(priv []byte, msg []byte) ([]byte, error) {
+	return Sign(priv, msg)
+}
+
+func (signer) Verify(sig []byte, a address.Address, msg []byte) error {
+	return Verify(sig, a.Payload(), msg)
+}
+
+func init() {
+	sigs.RegisterSignature(crypto.SigTypeBLS, signer{})
+}
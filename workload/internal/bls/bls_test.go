@@ -0,0 +1,123 @@
+package bls
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+)
+
+// knownAnswers were generated with supranational/blst (the backend of
+// filecoin-ffi's bls-signatures) using the min-pk scheme and DST. Private
+// keys are little-endian, as filecoin-ffi stores them.
+var knownAnswers = []struct {
+	priv, pub, msg, sig string
+}{
+	{
+		priv: "0fd12905cf47045d490beb1d9b86db2d456e5159e7bc5f38dfc1c4b867253c0b",
+		pub:  "b29dcf4060bff23a5581cf307c48019ecd20fa4299c44ff93d833f8cec7466ebd96a844c87ceddb7b9243ab7e8a71fdb",
+		msg:  "",
+		sig:  "aa95068982fb57c5ec68e50663c71b79da83a763552ec6cb6c321dffda09bfa67cfac49055c18dc1000792c4226ea29a0a13d9e0292e1e2b03a599bb661824b99ae1f5bddfc6612a4eef31b628850cd78d06b025b2fb2113bc1712c85cace094",
+	},
+	{
+		priv: "f5c855055bdb3ebd5db033462687536a91586dc0b25b68c17fab24b7863b2f0f",
+		pub:  "9893090c3afbc5dfc1ef426cc00cdeac120fe4491038ab4301edf045dcacde328ee9a1bacff8ce69320e1d43cd71cc43",
+		msg:  "filecoin",
+		sig:  "abc37ab6a0a71228ccdd2ccad0ab3871438371c8bf58b9f970446434098c7998c2297717888b2ebacecfff22c35d119a03ff45b4e31d1eeb4b2b176e01f414eebc57e0a8aa13dd2ce4e4d9391b4a9ffd5566e972af9e758c222a5f3cf412ba48",
+	},
+	{
+		priv: "5731317019a5ab826cf56866c98b6b3d624d1df47d922afef39461d08b319a37",
+		pub:  "b6781d6a1985c4e8c813f3fd91a400c787cf7644eb12b7cd1157b1ed567d528c19bb2f17e81c52fed9f5400e52d74741",
+		msg:  "stress-engine known-answer vector 3",
+		sig:  "a9811c71a366358cc9d9e546b6bbd64032b89b6b309f9450444cfe4a8064ef9561da7ded772920594ecde07691661f7e0be6725405d34dab61fb6016f1c5383d1d46e91470e769fe07ae8021ac4a89af26e40aab686b010313bd475cce61544e",
+	},
+}
+
+func mustHex(t *testing.T, s string) []byte {
+	t.Helper()
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		t.Fatalf("bad hex %q: %v", s, err)
+	}
+	return b
+}
+
+func TestKnownAnswers(t *testing.T) {
+	for i, ka := range knownAnswers {
+		priv, wantPub, wantSig := mustHex(t, ka.priv), mustHex(t, ka.pub), mustHex(t, ka.sig)
+		msg := []byte(ka.msg)
+
+		pub, err := PublicKey(priv)
+		if err != nil {
+			t.Fatalf("vector %d: PublicKey: %v", i, err)
+		}
+		if !bytes.Equal(pub, wantPub) {
+			t.Errorf("vector %d: public key\n got %x\nwant %x", i, pub, wantPub)
+		}
+
+		sig, err := Sign(priv, msg)
+		if err != nil {
+			t.Fatalf("vector %d: Sign: %v", i, err)
+		}
+		if !bytes.Equal(sig, wantSig) {
+			t.Errorf("vector %d: signature\n got %x\nwant %x", i, sig, wantSig)
+		}
+
+		if err := Verify(wantSig, wantPub, msg); err != nil {
+			t.Errorf("vector %d: reference signature rejected: %v", i, err)
+		}
+		if err := Verify(wantSig, wantPub, append(msg, 0)); err == nil {
+			t.Errorf("vector %d: reference signature verified for a different message", i)
+		}
+	}
+}
+
+func TestSignVerifyRoundTrip(t *testing.T) {
+	for i := 0; i < 8; i++ {
+		priv, err := signer{}.GenPrivate()
+		if err != nil {
+			t.Fatalf("GenPrivate: %v", err)
+		}
+		pub, err := PublicKey(priv)
+		if err != nil {
+			t.Fatalf("PublicKey: %v", err)
+		}
+		msg := []byte{byte(i), 'm', 's', 'g'}
+		sig, err := Sign(priv, msg)
+		if err != nil {
+			t.Fatalf("Sign: %v", err)
+		}
+		if err := Verify(sig, pub, msg); err != nil {
+			t.Errorf("round trip %d: %v", i, err)
+		}
+
+		other, err := signer{}.GenPrivate()
+		if err != nil {
+			t.Fatalf("GenPrivate: %v", err)
+		}
+		otherPub, err := PublicKey(other)
+		if err != nil {
+			t.Fatalf("PublicKey: %v", err)
+		}
+		if err := Verify(sig, otherPub, msg); err == nil {
+			t.Errorf("round trip %d: signature verified under another key", i)
+		}
+	}
+}
+
+func TestPrivateKeyFromSeedDeterministic(t *testing.T) {
+	seed := bytes.Repeat([]byte{0xab}, PrivateKeyBytes)
+	a, err := PrivateKeyFromSeed(seed)
+	if err != nil {
+		t.Fatalf("PrivateKeyFromSeed: %v", err)
+	}
+	b, err := PrivateKeyFromSeed(seed)
+	if err != nil {
+		t.Fatalf("PrivateKeyFromSeed: %v", err)
+	}
+	if !bytes.Equal(a, b) {
+		t.Fatalf("same seed gave %x and %x", a, b)
+	}
+	if _, err := PublicKey(a); err != nil {
+		t.Fatalf("derived key rejected: %v", err)
+	}
+}
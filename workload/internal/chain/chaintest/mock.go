@@ -0,0 +1,250 @@
+// Package chaintest provides an in-process api.FullNode for tests. It is
+// imported only from _test.go files and never linked into the engine.
+package chaintest
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/filecoin-project/lotus/api"
+	"github.com/filecoin-project/lotus/chain/types"
+	"github.com/ipfs/go-cid"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/multiformats/go-multihash"
+)
+
+// MockNode is an in-process api.FullNode with scripted responses, for driving
+// vectors deterministically without a devnet. Only the subset of methods the
+// vectors use is implemented; calling anything else panics on the nil
+// embedded interface, which flags a vector that needs a new mock method.
+//
+// Fields may be set directly before use. Methods are safe for concurrent use.
+type MockNode struct {
+	api.FullNode
+
+	mu sync.Mutex
+
+	Genesis   *types.TipSet
+	Head      *types.TipSet
+	Finalized *types.TipSet
+	TipSets   []*types.TipSet // canonical chain, any order
+
+	Actors         map[address.Address]*types.Actor
+	IDs            map[address.Address]address.Address
+	Nonces         map[address.Address]uint64
+	Lookups        map[cid.Cid]*api.MsgLookup
+	ParentMessages map[cid.Cid][]api.Message
+	ParentReceipts map[cid.Cid][]*types.MessageReceipt
+	Peers          []peer.AddrInfo
+
+	// PushErr, when set, is returned by MpoolPush instead of accepting.
+	PushErr error
+	// PushLimit, when positive, makes MpoolPush reject once this many
+	// messages have been accepted.
+	PushLimit int
+	// Pushed records every message accepted by MpoolPush, in order.
+	Pushed []*types.SignedMessage
+}
+
+// NewMockNode returns a MockNode whose chain is the given tipsets. The lowest
+// tipset is genesis; the highest is both head and finalized.
+func NewMockNode(tipsets ...*types.TipSet) *MockNode {
+	m := &MockNode{
+		TipSets:        tipsets,
+		Actors:         make(map[address.Address]*types.Actor),
+		IDs:            make(map[address.Address]address.Address),
+		Nonces:         make(map[address.Address]uint64),
+		Lookups:        make(map[cid.Cid]*api.MsgLookup),
+		ParentMessages: make(map[cid.Cid][]api.Message),
+		ParentReceipts: make(map[cid.Cid][]*types.MessageReceipt),
+	}
+	for _, ts := range tipsets {
+		if m.Genesis == nil || ts.Height() < m.Genesis.Height() {
+			m.Genesis = ts
+		}
+		if m.Head == nil || ts.Height() > m.Head.Height() {
+			m.Head = ts
+		}
+	}
+	m.Finalized = m.Head
+	return m
+}
+
+// MockTipSet builds a single-block tipset at height on top of parents with
+// the given parent state root. Distinct miner IDs yield distinct block CIDs
+// at the same height, which is how tests script forks.
+func MockTipSet(height abi.ChainEpoch, parents types.TipSetKey, stateRoot cid.Cid, miner uint64) *types.TipSet {
+	minerAddr, err := address.NewIDAddress(miner)
+	if err != nil {
+		panic(err)
+	}
+	placeholder := MockCid("empty")
+	ts, err := types.NewTipSet([]*types.BlockHeader{{
+		Miner:                 minerAddr,
+		Ticket:                &types.Ticket{VRFProof: []byte(fmt.Sprintf("ticket-%d-%d", height, miner))},
+		ElectionProof:         &types.ElectionProof{WinCount: 1},
+		Parents:               parents.Cids(),
+		ParentWeight:          big.NewInt(int64(height)),
+		Height:                height,
+		ParentStateRoot:       stateRoot,
+		ParentMessageReceipts: placeholder,
+		Messages:              placeholder,
+		ParentBaseFee:         big.NewInt(100),
+	}})
+	if err != nil {
+		panic(err)
+	}
+	return ts
+}
+
+// MockCid returns a deterministic CID derived from s.
+func MockCid(s string) cid.Cid {
+	c, err := cid.V1Builder{Codec: cid.DagCBOR, MhType: multihash.SHA2_256}.Sum([]byte(s))
+	if err != nil {
+		panic(err)
+	}
+	return c
+}
+
+func (m *MockNode) ChainHead(ctx context.Context) (*types.TipSet, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.Head, nil
+}
+
+func (m *MockNode) ChainGetFinalizedTipSet(ctx context.Context) (*types.TipSet, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.Finalized, nil
+}
+
+func (m *MockNode) ChainGetGenesis(ctx context.Context) (*types.TipSet, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.Genesis, nil
+}
+
+// ChainGetTipSetByHeight returns the highest tipset at or below h, matching
+// the real API's behaviour for null rounds. The anchor key is ignored.
+func (m *MockNode) ChainGetTipSetByHeight(ctx context.Context, h abi.ChainEpoch, tsk types.TipSetKey) (*types.TipSet, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var best *types.TipSet
+	for _, ts := range m.TipSets {
+		if ts.Height() <= h && (best == nil || ts.Height() > best.Height()) {
+			best = ts
+		}
+	}
+	if best == nil {
+		return nil, fmt.Errorf("mock: no tipset at or below height %d", h)
+	}
+	return best, nil
+}
+
+func (m *MockNode) ChainGetTipSet(ctx context.Context, tsk types.TipSetKey) (*types.TipSet, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, ts := range m.TipSets {
+		if ts.Key() == tsk {
+			return ts, nil
+		}
+	}
+	return nil, fmt.Errorf("mock: tipset %s not found", tsk)
+}
+
+func (m *MockNode) ChainGetParentMessages(ctx context.Context, blk cid.Cid) ([]api.Message, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.ParentMessages[blk], nil
+}
+
+func (m *MockNode) ChainGetParentReceipts(ctx context.Context, blk cid.Cid) ([]*types.MessageReceipt, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.ParentReceipts[blk], nil
+}
+
+func (m *MockNode) StateGetActor(ctx context.Context, addr address.Address, tsk types.TipSetKey) (*types.Actor, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	act, ok := m.Actors[addr]
+	if !ok {
+		return nil, fmt.Errorf("mock: actor %s not found", addr)
+	}
+	return act, nil
+}
+
+func (m *MockNode) StateLookupID(ctx context.Context, addr address.Address, tsk types.TipSetKey) (address.Address, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if addr.Protocol() == address.ID {
+		return addr, nil
+	}
+	id, ok := m.IDs[addr]
+	if !ok {
+		return address.Undef, fmt.Errorf("mock: no ID for %s", addr)
+	}
+	return id, nil
+}
+
+func (m *MockNode) StateSearchMsg(ctx context.Context, tsk types.TipSetKey, msg cid.Cid, limit abi.ChainEpoch, allowReplaced bool) (*api.MsgLookup, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.Lookups[msg], nil
+}
+
+func (m *MockNode) StateWaitMsg(ctx context.Context, msg cid.Cid, confidence uint64, limit abi.ChainEpoch, allowReplaced bool) (*api.MsgLookup, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	lookup, ok := m.Lookups[msg]
+	if !ok {
+		return nil, fmt.Errorf("mock: message %s never lands", msg)
+	}
+	return lookup, nil
+}
+
+func (m *MockNode) MpoolGetNonce(ctx context.Context, addr address.Address) (uint64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.Nonces[addr], nil
+}
+
+func (m *MockNode) MpoolPush(ctx context.Context, smsg *types.SignedMessage) (cid.Cid, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.PushErr != nil {
+		return cid.Undef, m.PushErr
+	}
+	if m.PushLimit > 0 && len(m.Pushed) >= m.PushLimit {
+		return cid.Undef, fmt.Errorf("mock: mpool full after %d messages", m.PushLimit)
+	}
+	m.Pushed = append(m.Pushed, smsg)
+	if smsg.Message.Nonce >= m.Nonces[smsg.Message.From] {
+		m.Nonces[smsg.Message.From] = smsg.Message.Nonce + 1
+	}
+	return smsg.Cid(), nil
+}
+
+func (m *MockNode) MpoolBatchPush(ctx context.Context, smsgs []*types.SignedMessage) ([]cid.Cid, error) {
+	cids := make([]cid.Cid, 0, len(smsgs))
+	for _, smsg := range smsgs {
+		c, err := m.MpoolPush(ctx, smsg)
+		if err != nil {
+			// go-jsonrpc never returns a result alongside an error, so the
+			// accepted prefix is not reported.
+			return nil, err
+		}
+		cids = append(cids, c)
+	}
+	return cids, nil
+}
+
+func (m *MockNode) NetPeers(ctx context.Context) ([]peer.AddrInfo, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.Peers, nil
+}
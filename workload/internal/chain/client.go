@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/filecoin-project/go-jsonrpc"
 	"github.com/filecoin-project/lotus/api"
@@ -18,20 +19,72 @@ type NodeConfig struct {
 	Names      []string // Node hostnames (e.g. ["lotus0", "lotus1", "forest0"])
 	Port       string   // RPC port for Lotus nodes (e.g. "1234")
 	ForestPort string   // RPC port for Forest nodes (e.g. "3456")
+	Transport  string   // "ws" (default) or "http"
+
+	// MaxAttempts and RetryDelay bound how long ConnectNodes waits for a node
+	// that is still starting; the delay doubles after each failed attempt.
+	// Zero values fall back to DefaultMaxAttempts and DefaultRetryDelay.
+	MaxAttempts int
+	RetryDelay  time.Duration
 }
 
+// Connection retry defaults used when NodeConfig leaves them unset.
+const (
+	DefaultMaxAttempts = 5
+	DefaultRetryDelay  = time.Second
+)
+
 // NewFilecoinClient creates an authenticated JSON-RPC client for a Filecoin node.
+// The addr scheme selects the transport: ws:// keeps one websocket open,
+// http:// sends each call as a separate POST.
 func NewFilecoinClient(ctx context.Context, addr string, token string) (api.FullNode, jsonrpc.ClientCloser, error) {
 	header := http.Header{}
 	header.Set("Authorization", "Bearer "+token)
 	return client.NewFullNodeRPCV1(ctx, addr, header)
 }
 
+// RPCAddr returns the /rpc/v1 endpoint of the named node using scheme
+// ("ws" or "http"), accounting for Forest's separate RPC port.
+func (c NodeConfig) RPCAddr(name, scheme string) string {
+	port := c.Port
+	if strings.HasPrefix(name, "forest") && c.ForestPort != "" {
+		port = c.ForestPort
+	}
+	return fmt.Sprintf("%s://%s:%s/rpc/v1", scheme, name, port)
+}
+
+// transport returns the configured RPC scheme, defaulting to websockets.
+func (c NodeConfig) transport() string {
+	if c.Transport == "http" {
+		return "http"
+	}
+	return "ws"
+}
+
+// NodeToken reads the named node's JWT from /root/devgen/<name>/<name>-jwt,
+// returning "" if it is missing.
+func NodeToken(name string) string {
+	tokenPath := fmt.Sprintf("/root/devgen/%s/%s-jwt", name, name)
+	tokenBytes, err := os.ReadFile(tokenPath)
+	if err != nil {
+		log.Printf("[chain] WARN: no JWT at %s for node %s, trying without auth", tokenPath, name)
+		return ""
+	}
+	return strings.TrimSpace(string(tokenBytes))
+}
+
 // ConnectNodes connects to all configured Filecoin nodes.
-// Returns connected nodes map, ordered key list, or error if no nodes connected.
-func ConnectNodes(ctx context.Context, cfg NodeConfig) (map[string]api.FullNode, []string, error) {
+// Returns connected nodes map, ordered key list, and a closeAll func that
+// tears down every connection, or an error if no nodes connected.
+func ConnectNodes(ctx context.Context, cfg NodeConfig) (map[string]api.FullNode, []string, func(), error) {
 	nodes := make(map[string]api.FullNode)
 	var keys []string
+	var closers []jsonrpc.ClientCloser
+	closeAll := func() {
+		for _, c := range closers {
+			c()
+		}
+	}
 
 	for _, name := range cfg.Names {
 		name = strings.TrimSpace(name)
@@ -39,27 +92,20 @@ func ConnectNodes(ctx context.Context, cfg NodeConfig) (map[string]api.FullNode,
 			continue
 		}
 
-		port := cfg.Port
-		if len(name) >= 6 && name[:6] == "forest" && cfg.ForestPort != "" {
-			port = cfg.ForestPort
-		}
-		addr := fmt.Sprintf("ws://%s:%s/rpc/v1", name, port)
+		addr := cfg.RPCAddr(name, cfg.transport())
+		token := NodeToken(name)
 
-		// JWT token path: /root/devgen/<nodename>/<nodename>-jwt
-		tokenPath := fmt.Sprintf("/root/devgen/%s/%s-jwt", name, name)
-		tokenBytes, err := os.ReadFile(tokenPath)
-		if err != nil {
-			log.Printf("[chain] WARN: no JWT at %s for node %s, trying without auth", tokenPath, name)
-			tokenBytes = []byte("")
+		node, closer, err := connectWithRetry(ctx, cfg, name, addr, token)
+		if ctx.Err() != nil {
+			closeAll()
+			return nil, nil, nil, ctx.Err()
 		}
-		token := strings.TrimSpace(string(tokenBytes))
-
-		node, closer, err := NewFilecoinClient(ctx, addr, token)
 		if err != nil {
 			log.Printf("[chain] ERROR: cannot connect to %s at %s: %v", name, addr, err)
 			continue
 		}
-		_ = closer // keep connection open for engine lifetime
+		node, closer = NewReconnectingClient(ctx, name, addr, token, node, closer)
+		closers = append(closers, closer)
 
 		nodes[name] = node
 		keys = append(keys, name)
@@ -67,8 +113,42 @@ func ConnectNodes(ctx context.Context, cfg NodeConfig) (map[string]api.FullNode,
 	}
 
 	if len(nodes) == 0 {
-		return nil, nil, fmt.Errorf("no nodes connected")
+		return nil, nil, nil, fmt.Errorf("no nodes connected")
 	}
 	log.Printf("[chain] connected to %d node(s): %v", len(nodes), keys)
-	return nodes, keys, nil
+	return nodes, keys, closeAll, nil
+}
+
+// connectWithRetry dials a node up to cfg.MaxAttempts times with exponential
+// backoff, so a node still starting up is not dropped for the whole run.
+func connectWithRetry(ctx context.Context, cfg NodeConfig, name, addr, token string) (api.FullNode, jsonrpc.ClientCloser, error) {
+	attempts := cfg.MaxAttempts
+	if attempts <= 0 {
+		attempts = DefaultMaxAttempts
+	}
+	delay := cfg.RetryDelay
+	if delay <= 0 {
+		delay = DefaultRetryDelay
+	}
+
+	var err error
+	for attempt := 1; ; attempt++ {
+		var node api.FullNode
+		var closer jsonrpc.ClientCloser
+		node, closer, err = NewFilecoinClient(ctx, addr, token)
+		if err == nil {
+			return node, closer, nil
+		}
+		if attempt >= attempts {
+			break
+		}
+		log.Printf("[chain] connect to %s failed (attempt %d/%d), retrying in %s: %v", name, attempt, attempts, delay, err)
+		select {
+		case <-ctx.Done():
+			return nil, nil, ctx.Err()
+		case <-time.After(delay):
+		}
+		delay *= 2
+	}
+	return nil, nil, fmt.Errorf("after %d attempts: %w", attempts, err)
 }
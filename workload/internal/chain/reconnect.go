@@ -0,0 +1,128 @@
+package chain
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/filecoin-project/go-jsonrpc"
+	"github.com/filecoin-project/lotus/api"
+)
+
+// reconnectingNode owns the live connection behind a self-healing client.
+// When a call fails with a connection error it re-dials the node with the
+// stored addr/token and retries the call once, so a node restarted by fault
+// injection comes back into the test surface instead of failing forever.
+type reconnectingNode struct {
+	ctx         context.Context
+	name        string
+	addr, token string
+
+	mu     sync.Mutex
+	cur    api.FullNode
+	closer jsonrpc.ClientCloser
+	gen    uint64 // bumped on every successful re-dial
+}
+
+// NewReconnectingClient wraps an established connection in an api.FullNode
+// whose every method re-dials and retries once on a connection error. The
+// returned closer closes whichever connection is current.
+func NewReconnectingClient(ctx context.Context, name, addr, token string, node api.FullNode, closer jsonrpc.ClientCloser) (api.FullNode, jsonrpc.ClientCloser) {
+	r := &reconnectingNode{ctx: ctx, name: name, addr: addr, token: token, cur: node, closer: closer}
+
+	var out api.FullNodeStruct
+	for _, internal := range api.GetInternalStructs(&out) {
+		rv := reflect.ValueOf(internal).Elem()
+		for i := 0; i < rv.NumField(); i++ {
+			field := rv.Field(i)
+			if field.Kind() != reflect.Func {
+				continue
+			}
+			method := rv.Type().Field(i).Name
+			field.Set(reflect.MakeFunc(field.Type(), func(args []reflect.Value) []reflect.Value {
+				return r.call(method, args)
+			}))
+		}
+	}
+	return &out, r.close
+}
+
+func (r *reconnectingNode) current() (api.FullNode, uint64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.cur, r.gen
+}
+
+func (r *reconnectingNode) call(method string, args []reflect.Value) []reflect.Value {
+	node, gen := r.current()
+	out := reflect.ValueOf(node).MethodByName(method).Call(args)
+	if !isConnError(out[len(out)-1]) {
+		return out
+	}
+
+	node, err := r.redial(gen)
+	if err != nil {
+		log.Printf("[chain] %s: re-dial after %s failed: %v", r.name, method, err)
+		return out
+	}
+	return reflect.ValueOf(node).MethodByName(method).Call(args)
+}
+
+// redial replaces the connection seen at generation gen. Concurrent callers
+// that hit the same dead connection share one re-dial.
+func (r *reconnectingNode) redial(gen uint64) (api.FullNode, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.gen != gen {
+		return r.cur, nil
+	}
+
+	node, closer, err := NewFilecoinClient(r.ctx, r.addr, r.token)
+	if err != nil {
+		return nil, err
+	}
+	r.closer()
+	r.cur, r.closer = node, closer
+	r.gen++
+	log.Printf("[chain] re-dialed node %s at %s", r.name, r.addr)
+	return node, nil
+}
+
+func (r *reconnectingNode) close() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.closer()
+}
+
+// isConnError reports whether a method's trailing error value means the
+// transport is gone rather than the node rejecting the request.
+func isConnError(v reflect.Value) bool {
+	if v.IsNil() {
+		return false
+	}
+	err, ok := v.Interface().(error)
+	if !ok {
+		return false
+	}
+	var connErr *jsonrpc.RPCConnectionError
+	if errors.As(err, &connErr) || errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return true
+	}
+	msg := err.Error()
+	for _, s := range []string{
+		"websocket routine exiting",
+		"connection refused",
+		"connection reset",
+		"broken pipe",
+		"use of closed network connection",
+	} {
+		if strings.Contains(msg, s) {
+			return true
+		}
+	}
+	return false
+}
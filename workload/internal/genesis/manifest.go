@@ -0,0 +1,31 @@
+// Package genesis holds the genesis manifest shared by genesis-prep, which
+// writes it next to the keystore, and the stress engine, which checks the
+// keystore it loads against it.
+package genesis
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+)
+
+// ManifestFile is the manifest's file name in the genesis-prep output dir.
+const ManifestFile = "genesis_manifest.json"
+
+// Manifest records the parameters a wallet set was generated with.
+type Manifest struct {
+	Seed                string `json:"Seed"`
+	Count               int    `json:"Count"`
+	Balance             string `json:"Balance"`
+	BalanceDistribution string `json:"BalanceDistribution"`
+	KeyType             string `json:"KeyType"`
+	Miners              int    `json:"Miners"`
+	AddressesSHA256     string `json:"AddressesSHA256"` // AddressChecksum of the keystore order
+}
+
+// AddressChecksum is the hex sha256 over the newline-joined wallet addresses
+// in keystore order.
+func AddressChecksum(addrs []string) string {
+	sum := sha256.Sum256([]byte(strings.Join(addrs, "\n")))
+	return hex.EncodeToString(sum[:])
+}